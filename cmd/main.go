@@ -1,8 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"time"
 
+	"github.com/Agronomety/ProjectManager/internal/cli"
 	"github.com/Agronomety/ProjectManager/internal/config"
 	"github.com/Agronomety/ProjectManager/internal/service"
 	"github.com/Agronomety/ProjectManager/internal/storage"
@@ -10,20 +17,192 @@ import (
 )
 
 func main() {
-	cfg, err := config.Load()
+	profile := flag.String("profile", "", "use the catalog of the named profile instead of the active one")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-profile name] [command [arguments]]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	baseConfig, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	db, err := storage.NewSQLiteStorage(cfg.DatabasePath)
+	cfg, err := baseConfig.ForProfile(*profile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	current, err := openSession(cfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
-	defer db.Close()
+	defer func() { current.Close() }()
 
-	projectRepo := storage.NewProjectRepository(db)
-	projectService := service.NewProjectService(projectRepo)
+	// Any arguments left after the flags select a command line command
+	// instead of the GUI.
+	if flag.NArg() > 0 {
+		err = cli.Run(context.Background(), current.cfg, current.projectService, flag.Args(), os.Stdin, os.Stdout, os.Stderr)
+		current.Close()
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	current.startMaintenance()
+
+	// switchProfile opens the catalog of another profile, and only closes the
+	// current one once that succeeded. The choice is remembered for the next start.
+	switchProfile := func(name string) (*config.Config, service.ProjectService, service.BackupService, error) {
+		cfg, err := baseConfig.ForProfile(name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		next, err := openSession(cfg)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		current.Close()
+		current = next
+		current.startMaintenance()
+
+		err = baseConfig.Update(map[string]interface{}{"active_profile": name})
+		if err != nil {
+			log.Printf("Failed to save active profile: %v", err)
+		}
 
-	app := ui.NewProjectManagerUI(projectService)
+		return cfg, current.projectService, current.backupService, nil
+	}
+
+	app := ui.NewProjectManagerUI(cfg, current.projectService, current.backupService, switchProfile)
 	app.Run()
 }
+
+// session is the open catalog of a profile.
+type session struct {
+	db             *storage.SQLiteStorage
+	projectService service.ProjectService
+	backupService  service.BackupService
+	cfg            *config.Config
+	// stop ends the maintenance started by startMaintenance.
+	stop context.CancelFunc
+}
+
+// openSession opens the database of a profile and creates its services.
+func openSession(cfg *config.Config) (*session, error) {
+	db, err := storage.NewSQLiteStorage(cfg.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	projectRepo := storage.NewProjectRepository(db)
+	return &session{
+		db:             db,
+		projectService: service.NewProjectService(projectRepo, cfg.CustomFields),
+		backupService:  service.NewBackupService(db, cfg.BackupDirectory, cfg.BackupCount, time.Duration(cfg.BackupIntervalHours)*time.Hour),
+		cfg:            cfg,
+		stop:           func() {},
+	}, nil
+}
+
+// startMaintenance purges expired trash, backs the database up and refreshes
+// the git status of the projects in the background until the session is
+// closed.
+func (s *session) startMaintenance() {
+	ctx, stop := context.WithCancel(context.Background())
+	s.stop = stop
+
+	if s.cfg.TrashRetentionDays > 0 {
+		go purgeTrash(ctx, s.projectService, time.Duration(s.cfg.TrashRetentionDays)*24*time.Hour)
+	}
+	if s.backupService.Interval() > 0 {
+		go runBackups(ctx, s.backupService)
+	}
+	if s.cfg.GitRefreshMinutes > 0 {
+		go refreshGitStatuses(ctx, s.projectService, time.Duration(s.cfg.GitRefreshMinutes)*time.Minute)
+	}
+}
+
+// Close stops the maintenance and closes the database. Closing twice is harmless.
+func (s *session) Close() {
+	s.stop()
+	s.db.Close()
+}
+
+// purgeTrash empties expired trash entries at startup and once a day after
+// that, until ctx is cancelled.
+func purgeTrash(ctx context.Context, projectService service.ProjectService, retention time.Duration) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		purged, err := projectService.PurgeExpiredTrash(ctx, retention)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to purge trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d project(s) from the trash", purged)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runBackups backs the database up at startup unless a recent backup exists,
+// and then once per configured interval, until ctx is cancelled.
+func runBackups(ctx context.Context, backupService service.BackupService) {
+	_, err := backupService.BackupIfDue(ctx)
+	if err != nil && ctx.Err() == nil {
+		log.Printf("Failed to back up database: %v", err)
+	}
+
+	ticker := time.NewTicker(backupService.Interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		backup, err := backupService.Backup(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Failed to back up database: %v", err)
+			}
+			continue
+		}
+		log.Printf("Backed up database to %s", backup.Path)
+	}
+}
+
+// refreshGitStatuses refreshes the git status of the projects at startup and
+// then once per interval, until ctx is cancelled.
+func refreshGitStatuses(ctx context.Context, projectService service.ProjectService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := projectService.RefreshGitStatuses(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to refresh git status: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
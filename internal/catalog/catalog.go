@@ -0,0 +1,446 @@
+// Package catalog reads and writes the project catalog as JSON, CSV or YAML
+// so that it can be moved between machines or kept under version control.
+//
+// JSON and YAML files hold a document with a format version and a list of
+// projects. CSV files hold one project per row with the columns
+//
+//	name,path,description,readme_path,icon,status,tags,groups,custom_fields,pinned_position,last_opened,created_at,deleted_at,history
+//
+// where tags, groups, custom_fields and history are the JSON encoding of the project's tags, group
+// paths, custom field values and revisions. Times are written in RFC 3339 and left empty when unset.
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// Version is the catalog format written by Encode.
+const Version = 1
+
+// Format is a catalog file format.
+type Format string
+
+const (
+	JSON Format = "json"
+	CSV  Format = "csv"
+	YAML Format = "yaml"
+)
+
+// ParseFormat validates a format name as given on the command line.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "json":
+		return JSON, nil
+	case "csv":
+		return CSV, nil
+	case "yaml", "yml":
+		return YAML, nil
+	}
+	return "", fmt.Errorf("unknown catalog format %q, expected json, csv or yaml", name)
+}
+
+// FormatFromPath picks the format from a file extension.
+func FormatFromPath(path string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "", fmt.Errorf("cannot tell the catalog format of %s, please specify one", path)
+	}
+	return ParseFormat(ext)
+}
+
+type document struct {
+	Version    int       `json:"version" yaml:"version"`
+	ExportedAt time.Time `json:"exported_at" yaml:"exported_at"`
+	Projects   []project `json:"projects" yaml:"projects"`
+}
+
+type project struct {
+	Name        string   `json:"name" yaml:"name"`
+	Path        string   `json:"path" yaml:"path"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	ReadmePath  string   `json:"readme_path,omitempty" yaml:"readme_path,omitempty"`
+	Icon        string   `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Status      string   `json:"status,omitempty" yaml:"status,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Groups holds the paths of the groups the project belongs to.
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+	// CustomFields holds the values of custom fields by field name.
+	CustomFields map[string]string `json:"custom_fields,omitempty" yaml:"custom_fields,omitempty"`
+	// PinnedPosition is the place of the project in the pinned list, 0 if unpinned.
+	PinnedPosition int        `json:"pinned_position,omitempty" yaml:"pinned_position,omitempty"`
+	LastOpened     *time.Time `json:"last_opened,omitempty" yaml:"last_opened,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty" yaml:"deleted_at,omitempty"`
+	History        []revision `json:"history,omitempty" yaml:"history,omitempty"`
+}
+
+type revision struct {
+	Revision  int       `json:"revision" yaml:"revision"`
+	ChangedAt time.Time `json:"changed_at" yaml:"changed_at"`
+	Changes   []change  `json:"changes" yaml:"changes"`
+}
+
+type change struct {
+	Field    string `json:"field" yaml:"field"`
+	OldValue string `json:"old" yaml:"old"`
+	NewValue string `json:"new" yaml:"new"`
+}
+
+var csvHeader = []string{"name", "path", "description", "readme_path", "icon", "status", "tags", "groups", "custom_fields", "pinned_position", "last_opened", "created_at", "deleted_at", "history"}
+
+// Encode writes the records to w in the given format.
+func Encode(w io.Writer, format Format, records []models.ProjectRecord) error {
+	doc := document{Version: Version, ExportedAt: time.Now().UTC(), Projects: []project{}}
+	for _, record := range records {
+		doc.Projects = append(doc.Projects, fromRecord(record))
+	}
+
+	switch format {
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	case YAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case CSV:
+		return encodeCSV(w, doc.Projects)
+	}
+	return fmt.Errorf("unknown catalog format %q", format)
+}
+
+// Decode reads records in the given format from r.
+func Decode(r io.Reader, format Format) ([]models.ProjectRecord, error) {
+	var doc document
+
+	switch format {
+	case JSON:
+		if err := json.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON catalog: %v", err)
+		}
+	case YAML:
+		if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML catalog: %v", err)
+		}
+	case CSV:
+		projects, err := decodeCSV(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV catalog: %v", err)
+		}
+		doc = document{Version: Version, Projects: projects}
+	default:
+		return nil, fmt.Errorf("unknown catalog format %q", format)
+	}
+
+	if doc.Version > Version {
+		return nil, fmt.Errorf("catalog format version %d is newer than the supported version %d", doc.Version, Version)
+	}
+
+	records := make([]models.ProjectRecord, 0, len(doc.Projects))
+	for i, p := range doc.Projects {
+		if p.Name == "" || p.Path == "" {
+			return nil, fmt.Errorf("project %d in catalog: name and path are required", i+1)
+		}
+		records = append(records, p.toRecord())
+	}
+
+	return records, nil
+}
+
+func fromRecord(record models.ProjectRecord) project {
+	p := project{
+		Name:           record.Project.Name,
+		Path:           record.Project.Path,
+		Description:    record.Project.Description,
+		ReadmePath:     record.Project.ReadmePath,
+		Icon:           record.Project.Icon,
+		Status:         string(record.Project.Status),
+		Tags:           record.Project.Tags,
+		Groups:         record.Groups,
+		CustomFields:   record.Project.CustomFields,
+		PinnedPosition: record.Project.PinnedPosition,
+		LastOpened:     timePtr(record.Project.LastOpened),
+		CreatedAt:      timePtr(record.Project.CreatedAt),
+		DeletedAt:      timePtr(record.Project.DeletedAt),
+	}
+
+	for _, rev := range record.History {
+		r := revision{Revision: rev.Revision, ChangedAt: rev.ChangedAt.UTC()}
+		for _, c := range rev.Changes {
+			r.Changes = append(r.Changes, change{Field: c.Field, OldValue: c.OldValue, NewValue: c.NewValue})
+		}
+		p.History = append(p.History, r)
+	}
+
+	return p
+}
+
+func (p project) toRecord() models.ProjectRecord {
+	record := models.ProjectRecord{
+		Project: models.Project{
+			Name:           p.Name,
+			Path:           p.Path,
+			Description:    p.Description,
+			ReadmePath:     p.ReadmePath,
+			Icon:           p.Icon,
+			Status:         models.Status(p.Status),
+			Tags:           p.Tags,
+			CustomFields:   p.CustomFields,
+			PinnedPosition: p.PinnedPosition,
+		},
+		Groups: p.Groups,
+	}
+	if p.LastOpened != nil {
+		record.Project.LastOpened = *p.LastOpened
+	}
+	if p.CreatedAt != nil {
+		record.Project.CreatedAt = *p.CreatedAt
+	}
+	if p.DeletedAt != nil {
+		record.Project.DeletedAt = *p.DeletedAt
+	}
+
+	for _, r := range p.History {
+		rev := models.ProjectRevision{Revision: r.Revision, ChangedAt: r.ChangedAt}
+		for _, c := range r.Changes {
+			rev.Changes = append(rev.Changes, models.FieldChange{Field: c.Field, OldValue: c.OldValue, NewValue: c.NewValue})
+		}
+		record.History = append(record.History, rev)
+	}
+
+	return record
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+func encodeCSV(w io.Writer, projects []project) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, p := range projects {
+		tags, err := encodeList(p.Tags)
+		if err != nil {
+			return fmt.Errorf("failed to encode tags of %s: %v", p.Path, err)
+		}
+		groups, err := encodeList(p.Groups)
+		if err != nil {
+			return fmt.Errorf("failed to encode groups of %s: %v", p.Path, err)
+		}
+
+		customFields := ""
+		if len(p.CustomFields) > 0 {
+			encoded, err := json.Marshal(p.CustomFields)
+			if err != nil {
+				return fmt.Errorf("failed to encode custom fields of %s: %v", p.Path, err)
+			}
+			customFields = string(encoded)
+		}
+
+		history := ""
+		if len(p.History) > 0 {
+			encoded, err := json.Marshal(p.History)
+			if err != nil {
+				return fmt.Errorf("failed to encode history of %s: %v", p.Path, err)
+			}
+			history = string(encoded)
+		}
+
+		err = writer.Write([]string{
+			p.Name,
+			p.Path,
+			p.Description,
+			p.ReadmePath,
+			p.Icon,
+			p.Status,
+			tags,
+			groups,
+			customFields,
+			formatPosition(p.PinnedPosition),
+			formatTime(p.LastOpened),
+			formatTime(p.CreatedAt),
+			formatTime(p.DeletedAt),
+			history,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func decodeCSV(r io.Reader) ([]project, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Columns are looked up by name so that hand-edited files may reorder or omit them.
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, required := range []string{"name", "path"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	var projects []project
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return row[i]
+		}
+
+		p := project{
+			Name:        field("name"),
+			Path:        field("path"),
+			Description: field("description"),
+			ReadmePath:  field("readme_path"),
+			Icon:        field("icon"),
+			Status:      field("status"),
+		}
+
+		if p.Tags, err = decodeList(field("tags")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid tags: %v", line, err)
+		}
+		if p.Groups, err = decodeList(field("groups")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid groups: %v", line, err)
+		}
+
+		if p.LastOpened, err = parseTime(field("last_opened")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid last_opened: %v", line, err)
+		}
+		if p.CreatedAt, err = parseTime(field("created_at")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid created_at: %v", line, err)
+		}
+		if p.DeletedAt, err = parseTime(field("deleted_at")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid deleted_at: %v", line, err)
+		}
+
+		if p.PinnedPosition, err = parsePosition(field("pinned_position")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid pinned_position: %v", line, err)
+		}
+
+		if customFields := field("custom_fields"); customFields != "" {
+			if err := json.Unmarshal([]byte(customFields), &p.CustomFields); err != nil {
+				return nil, fmt.Errorf("line %d: invalid custom_fields: %v", line, err)
+			}
+		}
+
+		if history := field("history"); history != "" {
+			if err := json.Unmarshal([]byte(history), &p.History); err != nil {
+				return nil, fmt.Errorf("line %d: invalid history: %v", line, err)
+			}
+		}
+
+		projects = append(projects, p)
+	}
+
+	return projects, nil
+}
+
+// encodeList writes a list CSV column as a JSON array, so that names may
+// contain any character. An empty list leaves the column empty.
+func encodeList(items []string) (string, error) {
+	if len(items) == 0 {
+		return "", nil
+	}
+	encoded, err := json.Marshal(items)
+	return string(encoded), err
+}
+
+// decodeList reads a list CSV column written by encodeList. Empty
+// entries are dropped.
+func decodeList(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var entries []string
+	if err := json.Unmarshal([]byte(value), &entries); err != nil {
+		return nil, err
+	}
+
+	var items []string
+	for _, item := range entries {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func formatPosition(position int) string {
+	if position <= 0 {
+		return ""
+	}
+	return strconv.Itoa(position)
+}
+
+func parsePosition(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,42 @@
+package catalog
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestCSVKeepsSeparatorsInNames(t *testing.T) {
+	records := []models.ProjectRecord{{
+		Project: models.Project{Name: "proj", Path: "/src/proj", Tags: []string{"client; acme", "go"}},
+		Groups:  []string{"Work/R&D; Labs", "Home"},
+	}}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, CSV, records); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	decoded, err := Decode(&buf, CSV)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("decoded %d records, want 1", len(decoded))
+	}
+	if got := decoded[0].Project.Tags; !slices.Equal(got, records[0].Project.Tags) {
+		t.Errorf("tags = %q, want %q", got, records[0].Project.Tags)
+	}
+	if got := decoded[0].Groups; !slices.Equal(got, records[0].Groups) {
+		t.Errorf("groups = %q, want %q", got, records[0].Groups)
+	}
+}
+
+func TestCSVRejectsUnencodedLists(t *testing.T) {
+	input := "name,path,tags\nproj,/src/proj,go;cli\n"
+	if _, err := Decode(strings.NewReader(input), CSV); err == nil {
+		t.Error("Decode accepted tags that are not a JSON array")
+	}
+}
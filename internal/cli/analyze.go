@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func runAnalyze(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "analyze")
+	all := flags.Bool("all", false, "analyze every project that is not archived")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *all {
+		if flags.NArg() > 0 {
+			return fmt.Errorf("analyze -all takes no project")
+		}
+		return analyzeAll(ctx, e)
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("analyze expects a project name or path, or -all")
+	}
+
+	project, err := findProject(ctx, e, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	stats, err := e.projectService.AnalyzeProject(ctx, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to analyze %s: %v", project.Name, err)
+	}
+
+	writer := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "LANGUAGE\tFILES\tCODE\tCOMMENTS\tBLANKS")
+	for _, lang := range append(stats.Languages, stats.Total()) {
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\n", lang.Language, lang.Files, lang.Code, lang.Comments, lang.Blanks)
+	}
+	return writer.Flush()
+}
+
+// analyzeAll analyzes every listed project and prints a line for each.
+func analyzeAll(ctx context.Context, e *env) error {
+	projects, err := e.projectService.ListProjects(ctx, models.ListOptions{SortBy: models.SortByName})
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %v", err)
+	}
+
+	writer := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tFILES\tCODE\tLANGUAGES")
+	failed := 0
+	for _, project := range projects {
+		stats, err := e.projectService.AnalyzeProject(ctx, project.ID)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "Failed to analyze %s: %v\n", project.Name, err)
+			failed++
+			continue
+		}
+
+		var languages []string
+		for _, lang := range stats.Languages {
+			languages = append(languages, lang.Language)
+		}
+		total := stats.Total()
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%s\n", project.Name, total.Files, total.Code, strings.Join(languages, ", "))
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d projects could not be analyzed", failed, len(projects))
+	}
+	return nil
+}
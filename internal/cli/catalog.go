@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Agronomety/ProjectManager/internal/catalog"
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func runExport(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "export")
+	formatName := flags.String("format", "", "catalog format: json, csv or yaml (default from the file extension, else json)")
+	output := flags.String("o", "", "file to write (default standard output)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := pickFormat(*formatName, *output, catalog.JSON)
+	if err != nil {
+		return err
+	}
+
+	records, err := e.projectService.ExportProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to export projects: %v", err)
+	}
+
+	if *output == "" {
+		return catalog.Encode(e.stdout, format, records)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", *output, err)
+	}
+
+	err = catalog.Encode(file, format, records)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", *output, err)
+	}
+
+	fmt.Fprintf(e.stderr, "Exported %d projects to %s\n", len(records), *output)
+	return nil
+}
+
+func runImport(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "import")
+	formatName := flags.String("format", "", "catalog format: json, csv or yaml (default from the file extension)")
+	mode := flags.String("mode", string(models.CatalogMerge), "merge adds and updates projects, replace removes all others first")
+	dryRun := flags.Bool("dry-run", false, "only report what would change")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("import expects one file name, or - for standard input")
+	}
+	input := flags.Arg(0)
+
+	var reader io.Reader = e.stdin
+	if input != "-" {
+		file, err := os.Open(input)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %v", input, err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	var format catalog.Format
+	var err error
+	if input == "-" {
+		format, err = pickFormat(*formatName, "", catalog.JSON)
+	} else {
+		format, err = pickFormat(*formatName, input, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	records, err := catalog.Decode(reader, format)
+	if err != nil {
+		return err
+	}
+
+	result, err := e.projectService.ImportCatalog(ctx, records, models.CatalogImportMode(*mode), *dryRun)
+	if err != nil {
+		return fmt.Errorf("import failed, no projects were imported: %v", err)
+	}
+
+	printImportResult(e.stdout, result)
+	return nil
+}
+
+// pickFormat uses the explicit format if given, then the file extension, then the fallback.
+func pickFormat(name, path string, fallback catalog.Format) (catalog.Format, error) {
+	if name != "" {
+		return catalog.ParseFormat(name)
+	}
+	if path == "" {
+		return fallback, nil
+	}
+	format, err := catalog.FormatFromPath(path)
+	if err != nil && fallback != "" {
+		return fallback, nil
+	}
+	return format, err
+}
+
+func printImportResult(w io.Writer, result models.CatalogImportResult) {
+	if result.DryRun {
+		fmt.Fprintln(w, "Dry run, nothing was changed.")
+	}
+	if result.Removed > 0 {
+		fmt.Fprintf(w, "Removed %d existing projects\n", result.Removed)
+	}
+
+	for _, group := range []struct {
+		label    string
+		projects []*models.Project
+	}{
+		{"Created", result.Created},
+		{"Updated", result.Updated},
+		{"Unchanged", result.Unchanged},
+	} {
+		fmt.Fprintf(w, "%s: %d\n", group.label, len(group.projects))
+		if group.label == "Unchanged" {
+			continue
+		}
+		for _, project := range group.projects {
+			fmt.Fprintf(w, "  %s (%s)\n", project.Name, project.Path)
+		}
+	}
+}
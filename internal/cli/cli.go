@@ -0,0 +1,143 @@
+// Package cli implements the command line interface, which shares the
+// database and services with the GUI:
+//
+//	ProjectManager <command> [flags] [arguments]
+//
+// Run "ProjectManager help" for the list of commands.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/Agronomety/ProjectManager/internal/config"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+// ErrUnknownCommand is returned by Run for commands it does not know.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// env is what commands have access to.
+type env struct {
+	// cfg is the configuration of the profile whose catalog is used.
+	cfg            *config.Config
+	projectService service.ProjectService
+	stdin          io.Reader
+	stdout         io.Writer
+	stderr         io.Writer
+}
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(ctx context.Context, e *env, args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{
+			name:    "list",
+			usage:   "list [-sort field] [-desc] [-limit n] [-offset n] [-tag tag]... [-group group]... [-status status]... [-archived] [-trashed]",
+			summary: "list projects, sorted and paged",
+			run:     runList,
+		},
+		{
+			name:    "groups",
+			usage:   "groups",
+			summary: "show the group tree with the number of projects in each group",
+			run:     runGroups,
+		},
+		{
+			name:    "status",
+			usage:   "status project [new-status]",
+			summary: "show the status history of a project, or change its status",
+			run:     runStatus,
+		},
+		{
+			name:    "pin",
+			usage:   "pin [-at n | -remove] [project]",
+			summary: "pin a project by name or path, or list the pinned projects",
+			run:     runPin,
+		},
+		{
+			name:    "analyze",
+			usage:   "analyze -all | project",
+			summary: "count lines of code per language and tag projects with their main languages",
+			run:     runAnalyze,
+		},
+		{
+			name:    "deps",
+			usage:   "deps [-scope runtime|dev|test] project",
+			summary: "list the dependencies declared in the manifests of a project",
+			run:     runDeps,
+		},
+		{
+			name:    "doctor",
+			usage:   "doctor -projects [-stale-days n] [-root dir]... [-fix] [-archive]",
+			summary: "report broken and neglected projects, and fix what can be fixed",
+			run:     runDoctor,
+		},
+		{
+			name:    "export",
+			usage:   "export [-format json|csv|yaml] [-o file]",
+			summary: "write the whole catalog, including tags, README links and history",
+			run:     runExport,
+		},
+		{
+			name:    "import",
+			usage:   "import [-format json|csv|yaml] [-mode merge|replace] [-dry-run] file",
+			summary: "read a catalog written by export",
+			run:     runImport,
+		},
+		{
+			name:    "help",
+			usage:   "help",
+			summary: "show this list of commands",
+			run:     runHelp,
+		},
+	}
+}
+
+// Run executes the command named by the first argument. cfg is the
+// configuration of the profile projectService belongs to, which provides
+// defaults such as the project folders.
+func Run(ctx context.Context, cfg *config.Config, projectService service.ProjectService, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	e := &env{cfg: cfg, projectService: projectService, stdin: stdin, stdout: stdout, stderr: stderr}
+
+	if len(args) == 0 {
+		return runHelp(ctx, e, nil)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(ctx, e, args[1:])
+		}
+	}
+
+	return fmt.Errorf("%w %q, run \"help\" for a list of commands", ErrUnknownCommand, args[0])
+}
+
+func runHelp(ctx context.Context, e *env, args []string) error {
+	fmt.Fprintln(e.stdout, "Usage: ProjectManager [-profile name] <command> [flags] [arguments]")
+	fmt.Fprintln(e.stdout, "Without a command the graphical interface is started.")
+	fmt.Fprintln(e.stdout, "With -profile the command uses the catalog of that profile instead of the active one.")
+	fmt.Fprintln(e.stdout)
+	fmt.Fprintln(e.stdout, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(e.stdout, "  %-110s %s\n", cmd.usage, cmd.summary)
+	}
+	return nil
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting.
+func newFlagSet(e *env, name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	return flags
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func runDeps(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "deps")
+	scopeName := flags.String("scope", "", "only show dependencies of this scope: runtime, dev or test")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("deps expects a project name or path")
+	}
+
+	var scope models.DependencyScope
+	if *scopeName != "" {
+		var err error
+		if scope, err = models.ParseDependencyScope(*scopeName); err != nil {
+			return err
+		}
+	}
+
+	project, err := findProject(ctx, e, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	// A scan with broken manifests still lists the dependencies of the others.
+	deps, scanErr := e.projectService.ScanDependencies(ctx, project.ID)
+	if deps == nil {
+		return fmt.Errorf("failed to scan %s: %v", project.Name, scanErr)
+	}
+
+	writer := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tVERSION\tSCOPE\tECOSYSTEM\tMANIFEST")
+	for _, dep := range deps.Dependencies {
+		if scope != "" && dep.Scope != scope {
+			continue
+		}
+		version := dep.Version
+		if dep.Indirect {
+			version += " (indirect)"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", dep.Name, version, dep.Scope, dep.Ecosystem, dep.Manifest)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if scanErr != nil {
+		return fmt.Errorf("some manifests of %s could not be parsed: %v", project.Name, scanErr)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+func runDoctor(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "doctor")
+	projects := flags.Bool("projects", false, "check the projects in the catalog")
+	staleDays := flags.Int("stale-days", e.cfg.StaleAfterDays, "report projects not opened in this many days (0 to skip)")
+	var roots stringList
+	flags.Var(&roots, "root", "also look for missing projects below this directory, besides the default project paths (repeatable)")
+	fix := flags.Bool("fix", false, "relocate moved projects and link detected README files")
+	archive := flags.Bool("archive", false, "archive stale projects")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("doctor takes no arguments")
+	}
+	if !*projects {
+		return fmt.Errorf("doctor needs something to check, such as -projects")
+	}
+
+	healthService := service.NewHealthService(e.projectService)
+	report, err := healthService.Check(ctx, models.HealthOptions{
+		StaleAfter:  time.Duration(*staleDays) * 24 * time.Hour,
+		SearchRoots: append(slices.Clip(e.cfg.DefaultProjectPaths), roots...),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check projects: %v", err)
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(e.stderr, "Checked %d projects, no problems found\n", report.Checked)
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "PROJECT\tISSUE\tDETAILS\tFIX")
+	for _, finding := range report.Findings {
+		fixName := "-"
+		if finding.Fix != models.FixNone {
+			fixName = string(finding.Fix)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", finding.Project.Name, finding.Issue, finding.Message, fixName)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "Checked %d projects, found %d problems\n", report.Checked, len(report.Findings))
+
+	fixed := 0
+	for _, finding := range report.Findings {
+		// Archiving is asked for separately, as it hides the project.
+		wanted := *fix
+		if finding.Fix == models.FixArchive {
+			wanted = *archive
+		}
+		if finding.Fix == models.FixNone || !wanted {
+			continue
+		}
+
+		err := healthService.Fix(ctx, finding)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "Failed to fix %s of %s: %v\n", finding.Issue, finding.Project.Name, err)
+			continue
+		}
+		fixed++
+	}
+	if *fix || *archive {
+		fmt.Fprintf(e.stderr, "Fixed %d problems\n", fixed)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func runGroups(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "groups")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	groups, err := e.projectService.ListGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list groups: %v", err)
+	}
+	if len(groups) == 0 {
+		fmt.Fprintln(e.stderr, "No groups")
+		return nil
+	}
+
+	// Groups are ordered by path, so each one follows its parent.
+	depth := make(map[int64]int, len(groups))
+	for _, group := range groups {
+		if group.ParentID != 0 {
+			depth[group.ID] = depth[group.ParentID] + 1
+		}
+		fmt.Fprintf(e.stdout, "%s%s (%d)\n", strings.Repeat("  ", depth[group.ID]), group.Name, group.ProjectCount)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// stringList collects the values of a flag that may be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func runList(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "list")
+	sortBy := flags.String("sort", string(models.SortByName), "sort by name, last_opened, created or path")
+	descending := flags.Bool("desc", false, "sort in descending order")
+	limit := flags.Int("limit", 0, "show at most this many projects (0 for all)")
+	offset := flags.Int("offset", 0, "skip this many projects")
+	trashed := flags.Bool("trashed", false, "include projects in the trash")
+	archived := flags.Bool("archived", false, "include archived projects")
+	var statuses stringList
+	flags.Var(&statuses, "status", "only list projects with this status (repeatable)")
+	var tags stringList
+	flags.Var(&tags, "tag", "only list projects with this tag (repeatable)")
+	var groups stringList
+	flags.Var(&groups, "group", "only list projects in this group or its subgroups, by name or path like Work/Clients (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	sortField, err := models.ParseSortField(*sortBy)
+	if err != nil {
+		return err
+	}
+
+	var statusFilter []models.Status
+	for _, name := range statuses {
+		status, err := models.ParseStatus(name)
+		if err != nil {
+			return err
+		}
+		statusFilter = append(statusFilter, status)
+	}
+
+	opts := models.ListOptions{
+		SortBy:          sortField,
+		Descending:      *descending,
+		Limit:           *limit,
+		Offset:          *offset,
+		Tags:            tags,
+		Groups:          groups,
+		Statuses:        statusFilter,
+		IncludeArchived: *archived,
+		IncludeTrashed:  *trashed,
+	}
+
+	projects, err := e.projectService.ListProjects(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %v", err)
+	}
+
+	writer := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tSTATUS\tPATH\tTAGS\tLAST OPENED")
+	for _, project := range projects {
+		lastOpened := "never"
+		if !project.LastOpened.IsZero() {
+			lastOpened = project.LastOpened.Local().Format("2006-01-02 15:04")
+		}
+		name := project.Name
+		if !project.DeletedAt.IsZero() {
+			name += " (trashed)"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", name, project.Status, project.Path, strings.Join(project.Tags, ", "), lastOpened)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if opts.Limit > 0 || opts.Offset > 0 {
+		total, err := e.projectService.CountProjects(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to count projects: %v", err)
+		}
+		if len(projects) > 0 {
+			fmt.Fprintf(e.stderr, "Showing %d-%d of %d projects\n", opts.Offset+1, opts.Offset+len(projects), total)
+		} else {
+			fmt.Fprintf(e.stderr, "No projects after offset %d of %d\n", opts.Offset, total)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+func runPin(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "pin")
+	at := flags.Int("at", 0, "pin at this position, starting at 1 (default last)")
+	remove := flags.Bool("remove", false, "unpin the project")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return listPinned(ctx, e)
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("pin expects one project name or path")
+	}
+
+	project, err := findProject(ctx, e, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *remove {
+		err = e.projectService.UnpinProject(ctx, project.ID)
+		if err != nil {
+			return fmt.Errorf("failed to unpin %s: %v", project.Name, err)
+		}
+		fmt.Fprintf(e.stderr, "Unpinned %s\n", project.Name)
+		return nil
+	}
+
+	err = e.projectService.PinProject(ctx, project.ID, *at)
+	if err != nil {
+		return fmt.Errorf("failed to pin %s: %v", project.Name, err)
+	}
+	return listPinned(ctx, e)
+}
+
+func listPinned(ctx context.Context, e *env) error {
+	projects, err := e.projectService.ListPinned(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pinned projects: %v", err)
+	}
+	if len(projects) == 0 {
+		fmt.Fprintln(e.stderr, "No pinned projects")
+		return nil
+	}
+
+	for _, project := range projects {
+		fmt.Fprintf(e.stdout, "%d. %s\t%s\n", project.PinnedPosition, project.Name, project.Path)
+	}
+	return nil
+}
+
+// findProject looks a project up by its path or, failing that, by its name,
+// ignoring case. Projects in the trash are not considered.
+func findProject(ctx context.Context, e *env, nameOrPath string) (*models.Project, error) {
+	projects, err := e.projectService.ListProjects(ctx, models.ListOptions{IncludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %v", err)
+	}
+
+	// Paths are stored normalised, so ~ and symbolic links must be resolved
+	// the same way to match.
+	if path, err := service.NormalizePath(nameOrPath); err == nil {
+		for i := range projects {
+			if projects[i].Path == path {
+				return &projects[i], nil
+			}
+		}
+	}
+
+	var matches []*models.Project
+	for i := range projects {
+		if strings.EqualFold(projects[i].Name, nameOrPath) {
+			matches = append(matches, &projects[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no project is named or located at %q", nameOrPath)
+	case 1:
+		return matches[0], nil
+	}
+
+	paths := make([]string, len(matches))
+	for i, project := range matches {
+		paths[i] = project.Path
+	}
+	return nil, fmt.Errorf("%d projects are named %q, give the path of one of them: %s", len(matches), nameOrPath, strings.Join(paths, ", "))
+}
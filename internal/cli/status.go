@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func runStatus(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "status")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		return fmt.Errorf("status expects a project name or path, optionally followed by the new status")
+	}
+
+	project, err := findProject(ctx, e, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 2 {
+		status, err := models.ParseStatus(flags.Arg(1))
+		if err != nil {
+			return err
+		}
+
+		err = e.projectService.ChangeStatus(ctx, project.ID, status)
+		if err != nil {
+			return fmt.Errorf("failed to change status of %s: %w", project.Name, err)
+		}
+		fmt.Fprintf(e.stderr, "%s is now %s\n", project.Name, status)
+		return nil
+	}
+
+	history, err := e.projectService.StatusHistory(ctx, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load status history: %v", err)
+	}
+
+	fmt.Fprintf(e.stdout, "%s is %s\n", project.Name, project.Status)
+	if next := project.Status.NextStatuses(); len(next) > 0 {
+		names := make([]string, len(next))
+		for i, status := range next {
+			names[i] = string(status)
+		}
+		fmt.Fprintf(e.stdout, "It can change to %s\n", strings.Join(names, ", "))
+	}
+	fmt.Fprintln(e.stdout)
+	for _, change := range history {
+		changedAt := change.ChangedAt.Local().Format("2006-01-02 15:04")
+		if change.From == "" {
+			fmt.Fprintf(e.stdout, "%s  added as %s\n", changedAt, change.To)
+		} else {
+			fmt.Fprintf(e.stdout, "%s  %s -> %s\n", changedAt, change.From, change.To)
+		}
+	}
+	return nil
+}
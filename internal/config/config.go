@@ -6,8 +6,14 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
 
 	"github.com/kirsle/configdir"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/query"
 )
 
 type Config struct {
@@ -15,6 +21,44 @@ type Config struct {
 	DefaultProjectPaths []string `json:"default_project_paths"`
 	VSCodePath          string   `json:"vscode_path"`
 	Theme               string   `json:"theme"`
+	// TrashRetentionDays is how long deleted projects stay in the trash
+	// before they are purged; zero or less keeps them until emptied by hand.
+	TrashRetentionDays int `json:"trash_retention_days"`
+	// BackupIntervalHours is how often the database is backed up; zero or
+	// less turns automatic backups off.
+	BackupIntervalHours int    `json:"backup_interval_hours"`
+	BackupCount         int    `json:"backup_count"`
+	BackupDirectory     string `json:"backup_directory"`
+	// StaleAfterDays is how long a project may go unopened before the
+	// health check reports it; zero or less turns that check off.
+	StaleAfterDays int `json:"stale_after_days"`
+	// GitRefreshMinutes is how often the git status of the projects is
+	// refreshed in the background; zero or less turns that off.
+	GitRefreshMinutes int `json:"git_refresh_minutes"`
+	// CustomFields defines extra fields shown and searchable on every project.
+	CustomFields []models.CustomFieldDefinition `json:"custom_fields"`
+	// Profiles are named catalogs, each with its own database. The settings
+	// above form the default profile.
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+	// ActiveProfile is the profile used when none is asked for.
+	ActiveProfile string `json:"active_profile,omitempty"`
+
+	// Profile is the name of the profile whose settings have been applied
+	// by ForProfile.
+	Profile string `json:"-"`
+}
+
+// DefaultProfile names the profile made of the top-level settings.
+const DefaultProfile = "default"
+
+// Profile holds the settings that differ between catalogs. Empty settings
+// are taken from the top level, except that the database and backups of a
+// profile are kept apart from those of the other profiles.
+type Profile struct {
+	DatabasePath        string   `json:"database_path"`
+	DefaultProjectPaths []string `json:"default_project_paths"`
+	VSCodePath          string   `json:"vscode_path"`
+	BackupDirectory     string   `json:"backup_directory"`
 }
 
 // DefaultConfig provides initial configuration values
@@ -28,7 +72,13 @@ func DefaultConfig() *Config {
 			filepath.Join(os.Getenv("HOME"), "Projects"),
 			filepath.Join(os.Getenv("USERPROFILE"), "Projects"),
 		},
-		Theme: "default",
+		Theme:               "default",
+		TrashRetentionDays:  30,
+		BackupIntervalHours: 24,
+		BackupCount:         7,
+		BackupDirectory:     filepath.Join(configPath, "backups"),
+		StaleAfterDays:      models.DefaultStaleDays,
+		GitRefreshMinutes:   15,
 	}
 }
 
@@ -54,13 +104,125 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %v", err)
 	}
 
-	var config Config
-	err = json.Unmarshal(configData, &config)
+	// Start from the defaults so settings missing from older files keep a sensible value
+	config := DefaultConfig()
+	err = json.Unmarshal(configData, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %v", err)
 	}
 
-	return &config, nil
+	err = validateCustomFields(config.CustomFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom_fields in config file: %v", err)
+	}
+
+	err = validateProfiles(config.Profiles)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profiles in config file: %v", err)
+	}
+
+	return config, nil
+}
+
+// validateProfiles checks that profile names can be used on the command line
+// and as directory names.
+func validateProfiles(profiles map[string]Profile) error {
+	for name := range profiles {
+		if name == DefaultProfile {
+			return fmt.Errorf("profile name %q is reserved for the top-level settings", name)
+		}
+		if name == "" || strings.IndexFunc(name, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-'
+		}) >= 0 {
+			return fmt.Errorf("profile name %q may only contain letters, digits, - and _", name)
+		}
+	}
+	return nil
+}
+
+// ProfileNames returns the default profile followed by the configured
+// profiles in alphabetical order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles)+1)
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...)
+}
+
+// ForProfile returns a copy of the configuration with the settings of the
+// named profile in place of the top-level ones. An empty name selects the
+// active profile. The copy is meant for reading; changes are saved through
+// the original.
+func (c *Config) ForProfile(name string) (*Config, error) {
+	if name == "" {
+		name = c.ActiveProfile
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	resolved := *c
+	resolved.Profile = name
+	if name == DefaultProfile {
+		return &resolved, nil
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q, expected one of %s", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	configPath := configdir.LocalConfig("ProjectManager")
+	resolved.DatabasePath = filepath.Join(configPath, "profiles", name, "projects.db")
+	resolved.BackupDirectory = filepath.Join(c.BackupDirectory, name)
+	if profile.DatabasePath != "" {
+		resolved.DatabasePath = profile.DatabasePath
+	}
+	if profile.BackupDirectory != "" {
+		resolved.BackupDirectory = profile.BackupDirectory
+	}
+	if len(profile.DefaultProjectPaths) > 0 {
+		resolved.DefaultProjectPaths = profile.DefaultProjectPaths
+	}
+	if profile.VSCodePath != "" {
+		resolved.VSCodePath = profile.VSCodePath
+	}
+
+	return &resolved, nil
+}
+
+// validateCustomFields checks that custom field names can be used as search
+// qualifiers and that every field has a usable type.
+func validateCustomFields(fields []models.CustomFieldDefinition) error {
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		name := strings.ToLower(field.Name)
+		if name == "" || strings.IndexFunc(name, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-'
+		}) >= 0 {
+			return fmt.Errorf("field name %q may only contain letters, digits, - and _", field.Name)
+		}
+		if query.IsBuiltinField(name) {
+			return fmt.Errorf("field name %q is reserved", field.Name)
+		}
+		if seen[name] {
+			return fmt.Errorf("field %q is defined twice", field.Name)
+		}
+		seen[name] = true
+
+		switch field.Type {
+		case models.CustomFieldString, models.CustomFieldNumber, models.CustomFieldDate, models.CustomFieldURL:
+		case models.CustomFieldEnum:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("enum field %q needs options", field.Name)
+			}
+		default:
+			return fmt.Errorf("field %q has unknown type %q, expected string, number, date, url or enum", field.Name, field.Type)
+		}
+	}
+	return nil
 }
 
 // Save writes the configuration to a file
@@ -102,6 +264,34 @@ func (c *Config) Update(updates map[string]interface{}) error {
 			if theme, ok := value.(string); ok {
 				c.Theme = theme
 			}
+		case "trash_retention_days":
+			if days, ok := value.(int); ok {
+				c.TrashRetentionDays = days
+			}
+		case "backup_interval_hours":
+			if hours, ok := value.(int); ok {
+				c.BackupIntervalHours = hours
+			}
+		case "backup_count":
+			if count, ok := value.(int); ok {
+				c.BackupCount = count
+			}
+		case "backup_directory":
+			if dir, ok := value.(string); ok {
+				c.BackupDirectory = dir
+			}
+		case "stale_after_days":
+			if days, ok := value.(int); ok {
+				c.StaleAfterDays = days
+			}
+		case "git_refresh_minutes":
+			if minutes, ok := value.(int); ok {
+				c.GitRefreshMinutes = minutes
+			}
+		case "active_profile":
+			if name, ok := value.(string); ok {
+				c.ActiveProfile = name
+			}
 		default:
 			log.Printf("Unknown config key: %s", key)
 		}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+// Backup is a copy of the project database.
+type Backup struct {
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+}
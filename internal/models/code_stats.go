@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// LanguageStats counts the files and lines of one language in a project.
+type LanguageStats struct {
+	Language string
+	Files    int
+	Code     int
+	Comments int
+	Blanks   int
+}
+
+// Lines returns the number of lines of all kinds.
+func (s LanguageStats) Lines() int {
+	return s.Code + s.Comments + s.Blanks
+}
+
+// CodeStats is the result of analysing the source files of a project.
+type CodeStats struct {
+	ProjectID int64
+	// Languages are ordered by lines of code, most first.
+	Languages  []LanguageStats
+	AnalyzedAt time.Time
+}
+
+// Total adds up the counts of all languages.
+func (s CodeStats) Total() LanguageStats {
+	total := LanguageStats{Language: "Total"}
+	for _, lang := range s.Languages {
+		total.Files += lang.Files
+		total.Code += lang.Code
+		total.Comments += lang.Comments
+		total.Blanks += lang.Blanks
+	}
+	return total
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldType is the kind of value a custom field holds.
+type CustomFieldType string
+
+const (
+	CustomFieldString CustomFieldType = "string"
+	CustomFieldNumber CustomFieldType = "number"
+	// CustomFieldDate values are stored as YYYY-MM-DD.
+	CustomFieldDate CustomFieldType = "date"
+	CustomFieldURL  CustomFieldType = "url"
+	// CustomFieldEnum values are one of the definition's options.
+	CustomFieldEnum CustomFieldType = "enum"
+)
+
+// CustomFieldDefinition describes a user-defined project field, as
+// configured in the custom_fields section of the config file.
+type CustomFieldDefinition struct {
+	// Name identifies the field in storage and in search queries, as in
+	// client:acme.
+	Name string `json:"name"`
+	// Label is shown in the GUI and defaults to the name.
+	Label   string          `json:"label,omitempty"`
+	Type    CustomFieldType `json:"type"`
+	Options []string        `json:"options,omitempty"`
+}
+
+// DisplayName returns the label of the field, or its name if it has none.
+func (d CustomFieldDefinition) DisplayName() string {
+	if d.Label != "" {
+		return d.Label
+	}
+	return d.Name
+}
+
+// Normalize checks a value against the field's type and returns it in its
+// stored form. Empty values are valid and clear the field.
+func (d CustomFieldDefinition) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	switch d.Type {
+	case CustomFieldString:
+		return value, nil
+
+	case CustomFieldNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a number", value)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+
+	case CustomFieldDate:
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a date like 2024-01-31", value)
+		}
+		return date.Format("2006-01-02"), nil
+
+	case CustomFieldURL:
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return "", fmt.Errorf("%q is not an absolute URL", value)
+		}
+		return value, nil
+
+	case CustomFieldEnum:
+		for _, option := range d.Options {
+			if strings.EqualFold(option, value) {
+				return option, nil
+			}
+		}
+		return "", fmt.Errorf("%q is not one of %s", value, strings.Join(d.Options, ", "))
+	}
+
+	return "", fmt.Errorf("unknown field type %q", d.Type)
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DependencyScope tells when a dependency is needed.
+type DependencyScope string
+
+const (
+	// ScopeRuntime dependencies are needed to build or run the project.
+	ScopeRuntime DependencyScope = "runtime"
+	// ScopeDev dependencies are development and build tools.
+	ScopeDev DependencyScope = "dev"
+	// ScopeTest dependencies are only needed by the tests.
+	ScopeTest DependencyScope = "test"
+)
+
+// DependencyScopes lists every scope.
+var DependencyScopes = []DependencyScope{ScopeRuntime, ScopeDev, ScopeTest}
+
+// ParseDependencyScope converts the name of a scope into a DependencyScope.
+func ParseDependencyScope(name string) (DependencyScope, error) {
+	for _, scope := range DependencyScopes {
+		if string(scope) == name {
+			return scope, nil
+		}
+	}
+	return "", fmt.Errorf("unknown dependency scope %q", name)
+}
+
+// Package ecosystems of dependencies.
+const (
+	EcosystemGo    = "go"
+	EcosystemNPM   = "npm"
+	EcosystemPyPI  = "pypi"
+	EcosystemMaven = "maven"
+	EcosystemCargo = "cargo"
+)
+
+// Dependency is a package a project declares in one of its manifests.
+type Dependency struct {
+	Ecosystem string
+	// Name identifies the package within its ecosystem, e.g. a Go module
+	// path or group:artifact for Maven.
+	Name string
+	// Version is the version or range as written in the manifest, or empty
+	// if none is given.
+	Version string
+	Scope   DependencyScope
+	// Manifest is the file declaring the dependency, relative to the
+	// project directory.
+	Manifest string
+	// Indirect dependencies are only needed by other dependencies, as
+	// marked in go.mod.
+	Indirect bool
+}
+
+// ProjectDependencies holds the dependencies found in the manifests of a project.
+type ProjectDependencies struct {
+	ProjectID    int64
+	Dependencies []Dependency
+	ScannedAt    time.Time
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// GitStatus is the state of the git repository of a project, as seen when
+// it was last inspected.
+type GitStatus struct {
+	ProjectID int64
+	// Branch is the checked out branch, or empty if HEAD is detached.
+	Branch string
+	// Upstream is the branch Branch tracks, such as origin/main, if any.
+	Upstream string
+	// Ahead and Behind count the commits not yet pushed to and pulled from
+	// Upstream.
+	Ahead  int
+	Behind int
+	// File counts: staged changes, unstaged changes, untracked files and
+	// unresolved merge conflicts.
+	Staged     int
+	Modified   int
+	Untracked  int
+	Conflicted int
+	// LastCommitAt is zero in a repository without commits.
+	LastCommitAt     time.Time
+	LastCommitAuthor string
+	// Remotes maps remote names to their fetch URLs.
+	Remotes   map[string]string
+	CheckedAt time.Time
+}
+
+// Dirty reports whether the working tree has changes or untracked files.
+func (s GitStatus) Dirty() bool {
+	return s.Staged+s.Modified+s.Untracked+s.Conflicted > 0
+}
@@ -0,0 +1,19 @@
+package models
+
+// GroupPathSeparator separates the names of nested groups in a group path.
+const GroupPathSeparator = "/"
+
+// Group is a named collection of projects. Groups nest through ParentID,
+// and a project can belong to any number of groups.
+type Group struct {
+	ID   int64
+	Name string
+	// ParentID is 0 for top-level groups.
+	ParentID int64
+	// Path is the name of the group prefixed with the names of its
+	// ancestors, as in Work/Clients.
+	Path string
+	// ProjectCount is the number of projects directly in the group,
+	// not counting the trash.
+	ProjectCount int
+}
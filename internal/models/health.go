@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// HealthIssue is a kind of problem found by the health check.
+type HealthIssue string
+
+const (
+	IssueMissingPath     HealthIssue = "missing_path"
+	IssueMissingReadme   HealthIssue = "missing_readme"
+	IssueDanglingReadme  HealthIssue = "dangling_readme"
+	IssueNoDescription   HealthIssue = "no_description"
+	IssueNoTags          HealthIssue = "no_tags"
+	IssueDuplicateRemote HealthIssue = "duplicate_remote"
+	IssueStale           HealthIssue = "stale"
+)
+
+// HealthFix is an automatic fix for a health issue.
+type HealthFix string
+
+const (
+	// FixNone means the issue has to be fixed by hand.
+	FixNone HealthFix = ""
+	// FixRelocate moves the project to the path in Target.
+	FixRelocate HealthFix = "relocate"
+	// FixDetectReadme links the README file in Target.
+	FixDetectReadme HealthFix = "detect_readme"
+	// FixArchive archives the project.
+	FixArchive HealthFix = "archive"
+)
+
+// DefaultStaleDays is how long a project may go unopened before the health
+// check reports it as stale.
+const DefaultStaleDays = 180
+
+// HealthOptions configures a health check.
+type HealthOptions struct {
+	// StaleAfter is how long a project may go unopened; zero or less turns
+	// the check off.
+	StaleAfter time.Duration
+	// SearchRoots are searched for the new location of missing projects.
+	SearchRoots []string
+}
+
+// HealthFinding is a problem with one project.
+type HealthFinding struct {
+	Project Project
+	Issue   HealthIssue
+	// Message describes the problem for people.
+	Message string
+	Fix     HealthFix
+	// Target is the path the fix uses, if it needs one.
+	Target string
+}
+
+// HealthReport lists the findings of a health check, ordered by project name.
+type HealthReport struct {
+	CheckedAt time.Time
+	// Checked is the number of projects checked.
+	Checked  int
+	Findings []HealthFinding
+}
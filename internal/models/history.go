@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Fields tracked in the change history of a project.
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+	FieldReadmePath  = "readme_path"
+	FieldIcon        = "icon"
+	// FieldPath changes are recorded by relocations and are not reverted.
+	FieldPath = "path"
+	// CustomFieldPrefix is followed by the name of a custom field.
+	CustomFieldPrefix = "custom:"
+	// FieldTags values are the sorted tag names as a JSON array, or empty
+	// for no tags.
+	FieldTags = "tags"
+)
+
+// FieldChange is the old and new value of a single changed project field.
+type FieldChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// ProjectRevision groups the field changes made by one update of a project.
+type ProjectRevision struct {
+	ProjectID int64
+	Revision  int
+	ChangedAt time.Time
+	Changes   []FieldChange
+}
@@ -0,0 +1,47 @@
+package models
+
+// ImportResult lists the outcome of importing a batch of projects.
+type ImportResult struct {
+	// Created holds the projects that were newly registered.
+	Created []*Project
+	// Existing holds projects whose path was already registered; their
+	// metadata was refreshed instead.
+	Existing []*Project
+	// Restored holds projects whose path belonged to a project in the
+	// trash, which was refreshed and brought back.
+	Restored []*Project
+}
+
+// ProjectRecord is a project together with its change history, as moved
+// between catalogs by export and import.
+type ProjectRecord struct {
+	Project Project
+	// History holds the project's revisions, newest first.
+	History []ProjectRevision
+	// Groups holds the paths of the groups the project belongs to.
+	Groups []string
+}
+
+// CatalogImportMode selects how an imported catalog is combined with the
+// stored projects.
+type CatalogImportMode string
+
+const (
+	// CatalogMerge adds new projects and overwrites the fields of projects
+	// with the same path; projects missing from the catalog are kept.
+	CatalogMerge CatalogImportMode = "merge"
+	// CatalogReplace removes every stored project, including the trash,
+	// before importing the catalog.
+	CatalogReplace CatalogImportMode = "replace"
+)
+
+// CatalogImportResult lists the outcome of importing a catalog.
+type CatalogImportResult struct {
+	Created   []*Project
+	Updated   []*Project
+	Unchanged []*Project
+	// Removed is the number of stored projects deleted by a replace.
+	Removed int
+	// DryRun is set when nothing was actually written.
+	DryRun bool
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Launch records a single time a project was opened and with which editor.
+type Launch struct {
+	ProjectID  int64
+	Editor     string
+	LaunchedAt time.Time
+}
+
+// StatsPeriod selects how launch counts are bucketed over time.
+type StatsPeriod string
+
+const (
+	StatsByDay  StatsPeriod = "day"
+	StatsByWeek StatsPeriod = "week"
+)
+
+// LaunchCount is the number of launches in one period, labelled like
+// "2024-05-31" for days or "2024-W22" for ISO weeks.
+type LaunchCount struct {
+	Period string
+	Count  int
+}
+
+// ProjectUsage is the number of launches of a single project.
+type ProjectUsage struct {
+	Project      Project
+	Count        int
+	LastLaunched time.Time
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+)
+
+// SortField is a column projects can be listed by.
+type SortField string
+
+const (
+	SortByName       SortField = "name"
+	SortByLastOpened SortField = "last_opened"
+	SortByCreated    SortField = "created"
+	SortByPath       SortField = "path"
+)
+
+// SortFields lists the valid sort fields in the order they are offered to users.
+var SortFields = []SortField{SortByName, SortByLastOpened, SortByCreated, SortByPath}
+
+// ParseSortField validates a sort field given by name.
+func ParseSortField(name string) (SortField, error) {
+	for _, field := range SortFields {
+		if string(field) == name {
+			return field, nil
+		}
+	}
+	return "", fmt.Errorf("unknown sort field %q, expected one of name, last_opened, created or path", name)
+}
+
+// ListOptions selects, orders and pages the projects returned by a listing.
+// The zero value lists every project that is neither archived nor in the
+// trash by name.
+type ListOptions struct {
+	// SortBy defaults to SortByName.
+	SortBy     SortField
+	Descending bool
+	// Limit is the maximum number of projects returned; zero means no limit.
+	Limit  int
+	Offset int
+	// Tags restricts the listing to projects that carry all of these tags.
+	Tags []string
+	// Groups restricts the listing to projects in all of these groups or
+	// their subgroups. Each entry is a group name, or a group path such as
+	// Work/Clients.
+	Groups []string
+	// Statuses restricts the listing to projects with one of these statuses.
+	Statuses []Status
+	// IncludeArchived also lists archived projects when Statuses is empty.
+	IncludeArchived bool
+	// IncludeTrashed also lists projects in the trash.
+	IncludeTrashed bool
+	// PinnedFirst lists pinned projects in pin order before all others.
+	PinnedFirst bool
+}
@@ -13,4 +13,19 @@ type Project struct {
 	LastOpened  time.Time
 	Tags        []string
 	Icon        string
+	CreatedAt   time.Time
+	// GitRootCommit identifies the project's repository after it moved,
+	// see git.RootCommit. It is empty for projects that are not in git.
+	GitRootCommit string
+	// CustomFields holds the values of user-defined fields by field name,
+	// see CustomFieldDefinition.
+	CustomFields map[string]string
+	// Status is the lifecycle stage of the project; it changes through
+	// the transitions allowed by Status.CanTransition.
+	Status Status
+	// PinnedPosition orders pinned projects, starting at 1. It is 0 for
+	// projects that are not pinned.
+	PinnedPosition int
+	// DeletedAt is set while the project is in the trash.
+	DeletedAt time.Time
 }
@@ -0,0 +1,21 @@
+package models
+
+// How a relocation candidate was matched to a missing project.
+const (
+	MatchedByRootCommit = "same git history"
+	MatchedByName       = "same folder name"
+)
+
+// RelocationCandidate is a directory that may be the new location of a
+// project whose path no longer exists.
+type RelocationCandidate struct {
+	Path      string
+	MatchedBy string
+}
+
+// MissingProject is a project whose path no longer exists, with the
+// directories it may have moved to, best matches first.
+type MissingProject struct {
+	Project    Project
+	Candidates []RelocationCandidate
+}
@@ -0,0 +1,26 @@
+package models
+
+// SnippetFragment is a piece of a search snippet. Highlighted fragments
+// contain the text that matched the query.
+type SnippetFragment struct {
+	Text        string
+	Highlighted bool
+}
+
+// SearchResult is a project matched by a full-text search, ordered by Rank
+// (lower is better) and accompanied by a snippet of the matching text.
+type SearchResult struct {
+	Project Project
+	Rank    float64
+	Snippet []SnippetFragment
+}
+
+// FuzzyMatch is a project matched by the fuzzy finder. Score combines the
+// match quality with how often and how recently the project was opened,
+// higher is better. NamePositions holds the rune indexes of the matched
+// characters in the project name, if the name matched.
+type FuzzyMatch struct {
+	Project       Project
+	Score         float64
+	NamePositions []int
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status is the lifecycle stage of a project.
+type Status string
+
+const (
+	StatusIdea        Status = "idea"
+	StatusActive      Status = "active"
+	StatusPaused      Status = "paused"
+	StatusMaintenance Status = "maintenance"
+	StatusDone        Status = "done"
+	// StatusArchived projects are left out of listings unless asked for.
+	StatusArchived Status = "archived"
+)
+
+// FieldStatus names the status field in validation errors.
+const FieldStatus = "status"
+
+// DefaultStatus is the status of projects created without one.
+const DefaultStatus = StatusActive
+
+// Statuses lists every status in lifecycle order.
+var Statuses = []Status{StatusIdea, StatusActive, StatusPaused, StatusMaintenance, StatusDone, StatusArchived}
+
+// statusTransitions lists the statuses each status may change to.
+var statusTransitions = map[Status][]Status{
+	StatusIdea:        {StatusActive, StatusArchived},
+	StatusActive:      {StatusPaused, StatusMaintenance, StatusDone, StatusArchived},
+	StatusPaused:      {StatusActive, StatusArchived},
+	StatusMaintenance: {StatusActive, StatusPaused, StatusDone, StatusArchived},
+	StatusDone:        {StatusActive, StatusMaintenance, StatusArchived},
+	StatusArchived:    {StatusIdea, StatusActive},
+}
+
+// ParseStatus validates a status given by name.
+func ParseStatus(name string) (Status, error) {
+	for _, status := range Statuses {
+		if string(status) == name {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status %q, expected one of idea, active, paused, maintenance, done or archived", name)
+}
+
+// NextStatuses returns the statuses a project with this status may change to.
+func (s Status) NextStatuses() []Status {
+	return statusTransitions[s]
+}
+
+// CanTransition reports whether a project may change from status s to next.
+func (s Status) CanTransition(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChange is a recorded transition between two statuses. From is empty
+// for the status a project was created with.
+type StatusChange struct {
+	ProjectID int64
+	From      Status
+	To        Status
+	ChangedAt time.Time
+}
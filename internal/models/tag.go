@@ -0,0 +1,8 @@
+package models
+
+// Tag is a label attached to one or more projects, together with the
+// number of projects currently using it.
+type Tag struct {
+	Name  string
+	Count int
+}
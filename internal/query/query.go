@@ -0,0 +1,365 @@
+// Package query parses the search syntax accepted by the project search bar.
+//
+// A query is a list of terms separated by whitespace. Terms are combined with
+// AND unless they are joined by the OR keyword, which binds tighter than the
+// implicit AND, so `tag:go OR tag:rust api` means (go OR rust) AND api.
+//
+// Supported terms:
+//
+//	word            free text, matched as a prefix against all indexed text
+//	"some phrase"   free text matched as an exact phrase
+//	-term           negates any other term
+//	name:api        project name contains "api"
+//	path:~/work     project path starts with (absolute) or contains the value
+//	tag:go          project has the tag "go"
+//	status:active   project has the status "active"
+//	group:clients   project is in a group named "clients" or in one of its
+//	                subgroups; group:Work/Clients names a group by its path
+//	opened:<7d      opened less than 7 days ago (units: h, d, w, m, y)
+//	opened:>30d     not opened for more than 30 days, including never
+//	opened:>2024-01-31 / opened:<2024-01-31   opened after / before a date
+//	opened:never    never opened
+//	client:acme     custom field "client" contains "acme", see ParseWithFields
+//	budget:>1000    custom field compared with < or >, numerically for numbers,
+//	                in calendar order for dates like 2024-01-31
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// Field names understood by the parser.
+const (
+	FieldName   = "name"
+	FieldPath   = "path"
+	FieldTag    = "tag"
+	FieldGroup  = "group"
+	FieldStatus = "status"
+	FieldOpened = "opened"
+)
+
+var knownFields = map[string]bool{
+	FieldName:   true,
+	FieldPath:   true,
+	FieldTag:    true,
+	FieldGroup:  true,
+	FieldStatus: true,
+	FieldOpened: true,
+}
+
+// IsBuiltinField reports whether name is one of the fields above, which
+// custom fields cannot use as their name.
+func IsBuiltinField(name string) bool {
+	return knownFields[strings.ToLower(name)]
+}
+
+// Comparison operators used by the opened: qualifier and custom fields.
+const (
+	OpNone    = ""
+	OpLess    = "<"
+	OpGreater = ">"
+)
+
+// Term is a single condition of a query.
+type Term struct {
+	// Field is empty for free text terms.
+	Field string
+	Value string
+	// Phrase is set for quoted free text that has to match exactly.
+	Phrase  bool
+	Negated bool
+	// Pos is the byte offset of the term in the original input.
+	Pos int
+
+	// Custom is set for terms on a custom field, which is named by Field.
+	Custom bool
+
+	// Op is set for opened: terms and for custom field comparisons. Age and
+	// Date are only set for opened: terms, where exactly one of them is
+	// non-zero unless Never is set.
+	Op    string
+	Age   time.Duration
+	Date  time.Time
+	Never bool
+}
+
+// Group is a list of terms of which at least one has to match.
+type Group []Term
+
+// Query is a list of groups that all have to match.
+type Query struct {
+	Groups []Group
+}
+
+// IsEmpty reports whether the query contains no terms at all.
+func (q *Query) IsEmpty() bool {
+	return q == nil || len(q.Groups) == 0
+}
+
+// SyntaxError describes a problem in the query text and where it occurred.
+type SyntaxError struct {
+	// Pos is the byte offset of the problem in the input.
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+// token is a lexical element of the query: a term or the OR keyword.
+type token struct {
+	text   string
+	pos    int
+	quoted bool
+	or     bool
+}
+
+// Parse parses a search query.
+func Parse(input string) (*Query, error) {
+	return ParseWithFields(input, nil)
+}
+
+// ParseWithFields parses a search query that may also use the given custom
+// field names as qualifiers.
+func ParseWithFields(input string, customFields []string) (*Query, error) {
+	custom := make(map[string]bool, len(customFields))
+	for _, name := range customFields {
+		custom[strings.ToLower(name)] = true
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &Query{}
+	joinNext := false
+
+	for i, tok := range tokens {
+		if tok.or {
+			if i == 0 || tokens[i-1].or {
+				return nil, &SyntaxError{Pos: tok.pos, Msg: "OR must follow a search term"}
+			}
+			if i == len(tokens)-1 {
+				return nil, &SyntaxError{Pos: tok.pos, Msg: "OR must be followed by a search term"}
+			}
+			joinNext = true
+			continue
+		}
+
+		term, err := parseTerm(tok, custom)
+		if err != nil {
+			return nil, err
+		}
+
+		if joinNext {
+			last := len(query.Groups) - 1
+			query.Groups[last] = append(query.Groups[last], term)
+			joinNext = false
+		} else {
+			query.Groups = append(query.Groups, Group{term})
+		}
+	}
+
+	return query, nil
+}
+
+// tokenize splits the input into whitespace separated tokens, keeping
+// quoted sections together and remembering where every token started.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+
+	for i < len(input) {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		if unicode.IsSpace(r) {
+			i += size
+			continue
+		}
+
+		start := i
+		var text strings.Builder
+		quoted := false
+
+		for i < len(input) {
+			r, size := utf8.DecodeRuneInString(input[i:])
+			if unicode.IsSpace(r) {
+				break
+			}
+			if r != '"' {
+				text.WriteString(input[i : i+size])
+				i += size
+				continue
+			}
+
+			quoteStart := i
+			end := strings.IndexByte(input[i+1:], '"')
+			if end < 0 {
+				return nil, &SyntaxError{Pos: quoteStart, Msg: "unterminated quoted phrase"}
+			}
+			quoted = true
+			// Keep the quotes so parseTerm can tell a quoted value apart.
+			text.WriteString(input[i : i+end+2])
+			i += end + 2
+		}
+
+		raw := text.String()
+		tokens = append(tokens, token{
+			text:   raw,
+			pos:    start,
+			quoted: quoted,
+			or:     raw == "OR",
+		})
+	}
+
+	return tokens, nil
+}
+
+// parseTerm turns a single token into a term.
+func parseTerm(tok token, custom map[string]bool) (Term, error) {
+	term := Term{Pos: tok.pos}
+	text := tok.text
+	offset := 0
+
+	if strings.HasPrefix(text, "-") && len(text) > 1 {
+		term.Negated = true
+		text = text[1:]
+		offset = 1
+	}
+
+	if strings.HasPrefix(text, `"`) {
+		value := unquote(text)
+		if !strings.ContainsFunc(value, isWordRune) {
+			return term, &SyntaxError{Pos: tok.pos + offset, Msg: "empty phrase"}
+		}
+		term.Value = value
+		term.Phrase = true
+		return term, nil
+	}
+
+	colon := strings.IndexByte(text, ':')
+	if colon <= 0 || strings.Contains(text[:colon], `"`) {
+		term.Value = unquote(text)
+		if !strings.ContainsFunc(term.Value, isWordRune) {
+			return term, &SyntaxError{Pos: tok.pos + offset, Msg: fmt.Sprintf("%q contains nothing to search for", term.Value)}
+		}
+		return term, nil
+	}
+
+	field := strings.ToLower(text[:colon])
+	if !knownFields[field] && !custom[field] {
+		return term, &SyntaxError{Pos: tok.pos + offset, Msg: fmt.Sprintf("unknown field %q", text[:colon])}
+	}
+
+	valuePos := tok.pos + offset + colon + 1
+	value := unquote(text[colon+1:])
+	if value == "" {
+		return term, &SyntaxError{Pos: valuePos, Msg: fmt.Sprintf("missing value for %s:", field)}
+	}
+
+	term.Field = field
+	term.Value = value
+
+	if field == FieldOpened {
+		if err := parseOpened(&term, valuePos); err != nil {
+			return term, err
+		}
+	} else if field == FieldStatus {
+		status, err := models.ParseStatus(strings.ToLower(value))
+		if err != nil {
+			return term, &SyntaxError{Pos: valuePos, Msg: err.Error()}
+		}
+		term.Value = string(status)
+	} else if custom[field] {
+		term.Custom = true
+		if strings.HasPrefix(value, OpLess) || strings.HasPrefix(value, OpGreater) {
+			term.Op = value[:1]
+			term.Value = value[1:]
+			if term.Value == "" {
+				return term, &SyntaxError{Pos: valuePos + 1, Msg: fmt.Sprintf("missing value to compare %s: with", field)}
+			}
+		}
+	}
+
+	return term, nil
+}
+
+// isWordRune reports whether r can be part of an indexed word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// unquote removes every double quote from a token; quotes only group text.
+func unquote(text string) string {
+	return strings.ReplaceAll(text, `"`, "")
+}
+
+// parseOpened interprets the value of an opened: qualifier.
+func parseOpened(term *Term, pos int) error {
+	value := strings.ToLower(term.Value)
+	if value == "never" {
+		term.Never = true
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(value, OpLess):
+		term.Op = OpLess
+	case strings.HasPrefix(value, OpGreater):
+		term.Op = OpGreater
+	default:
+		return &SyntaxError{Pos: pos, Msg: "opened: needs < or > followed by an age like 7d or a date like 2024-01-31"}
+	}
+	value = value[1:]
+	pos++
+
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		term.Date = date
+		return nil
+	}
+
+	age, err := parseAge(value)
+	if err != nil {
+		return &SyntaxError{Pos: pos, Msg: err.Error()}
+	}
+	term.Age = age
+
+	return nil
+}
+
+// parseAge parses ages such as 12h, 7d, 2w, 3m and 1y. Months are 30 days
+// and years 365 days long.
+func parseAge(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid age %q, expected a number followed by h, d, w, m or y", value)
+	}
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid age %q, expected a number followed by h, d, w, m or y", value)
+	}
+
+	day := 24 * time.Hour
+	units := map[byte]time.Duration{
+		'h': time.Hour,
+		'd': day,
+		'w': 7 * day,
+		'm': 30 * day,
+		'y': 365 * day,
+	}
+
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit in %q, expected h, d, w, m or y", value)
+	}
+
+	return time.Duration(n) * unit, nil
+}
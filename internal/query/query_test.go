@@ -0,0 +1,105 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		input string
+		want  []Group
+	}{
+		{"", nil},
+		{"api", []Group{{{Value: "api"}}}},
+		{"name:api tag:go", []Group{
+			{{Field: FieldName, Value: "api"}},
+			{{Field: FieldTag, Value: "go", Pos: 9}},
+		}},
+		{"TAG:Go", []Group{{{Field: FieldTag, Value: "Go"}}}},
+		{"status:Active", []Group{{{Field: FieldStatus, Value: "active"}}}},
+		{"path:~/work", []Group{{{Field: FieldPath, Value: "~/work"}}}},
+		{`group:"Work/R&D Labs"`, []Group{{{Field: FieldGroup, Value: "Work/R&D Labs"}}}},
+		{"-tag:archived", []Group{{{Field: FieldTag, Value: "archived", Negated: true}}}},
+		{"tag:go OR tag:rust api", []Group{
+			{{Field: FieldTag, Value: "go"}, {Field: FieldTag, Value: "rust", Pos: 10}},
+			{{Value: "api", Pos: 19}},
+		}},
+		{`"hello world" -"old stuff"`, []Group{
+			{{Value: "hello world", Phrase: true}},
+			{{Value: "old stuff", Phrase: true, Negated: true, Pos: 14}},
+		}},
+		{"opened:<7d", []Group{{{Field: FieldOpened, Value: "<7d", Op: OpLess, Age: 7 * day}}}},
+		{"opened:>2w", []Group{{{Field: FieldOpened, Value: ">2w", Op: OpGreater, Age: 14 * day}}}},
+		{"opened:never", []Group{{{Field: FieldOpened, Value: "never", Never: true}}}},
+		{"voilà Åsa", []Group{
+			{{Value: "voilà"}},
+			{{Value: "Åsa", Pos: 7}},
+		}},
+		{"café bar", []Group{
+			{{Value: "café"}},
+			{{Value: "bar", Pos: 7}},
+		}},
+		{"tag:日本語", []Group{{{Field: FieldTag, Value: "日本語"}}}},
+	}
+	for _, test := range tests {
+		query, err := Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(query.Groups, test.want) {
+			t.Errorf("Parse(%q) = %+v, want %+v", test.input, query.Groups, test.want)
+		}
+	}
+}
+
+func TestParseCustomFields(t *testing.T) {
+	query, err := ParseWithFields("Client:acme budget:>1000", []string{"client", "Budget"})
+	if err != nil {
+		t.Fatalf("ParseWithFields: %v", err)
+	}
+	want := []Group{
+		{{Field: "client", Value: "acme", Custom: true}},
+		{{Field: "budget", Value: "1000", Op: OpGreater, Custom: true, Pos: 12}},
+	}
+	if !reflect.DeepEqual(query.Groups, want) {
+		t.Errorf("ParseWithFields = %+v, want %+v", query.Groups, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{`api "open`, 4},
+		{"OR api", 0},
+		{"api OR", 4},
+		{"api OR OR go", 7},
+		{"colour:red", 0},
+		{"-colour:red", 1},
+		{"tag:", 4},
+		{`""`, 0},
+		{"---", 1},
+		{"status:bogus", 7},
+		{"opened:7d", 7},
+		{"opened:<7x", 8},
+		{"é tag:", 7},
+		{`Åsa "open`, 5},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.input)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q) = %v, want a *SyntaxError", test.input, err)
+			continue
+		}
+		if syntaxErr.Pos != test.pos {
+			t.Errorf("Parse(%q) error at %d (%v), want %d", test.input, syntaxErr.Pos, err, test.pos)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+)
+
+// ErrCorrupt is returned when the database fails its integrity check.
+var ErrCorrupt = storage.ErrCorrupt
+
+// ErrNoGoodBackup is returned by RestoreLatestGood when no backup passes the integrity check.
+var ErrNoGoodBackup = errors.New("no intact backup found")
+
+// BackupService keeps rotated backups of the project database.
+type BackupService interface {
+	// Backup checks the database and writes a new backup, then deletes
+	// backups beyond the configured count.
+	Backup(ctx context.Context) (models.Backup, error)
+	// BackupIfDue backs up only if the newest backup is older than the
+	// configured interval, and reports whether it did.
+	BackupIfDue(ctx context.Context) (bool, error)
+	ListBackups() ([]models.Backup, error)
+	// CheckIntegrity returns an error wrapping ErrCorrupt if the database is damaged.
+	CheckIntegrity(ctx context.Context) error
+	// RestoreLatestGood restores the newest backup that passes the
+	// integrity check and returns it.
+	RestoreLatestGood(ctx context.Context) (models.Backup, error)
+	// Interval is the configured time between automatic backups; zero
+	// means automatic backups are off.
+	Interval() time.Duration
+}
+
+type DefaultBackupService struct {
+	storage  *storage.SQLiteStorage
+	dir      string
+	keep     int
+	interval time.Duration
+}
+
+func NewBackupService(storage *storage.SQLiteStorage, dir string, keep int, interval time.Duration) BackupService {
+	return &DefaultBackupService{
+		storage:  storage,
+		dir:      dir,
+		keep:     keep,
+		interval: max(interval, 0),
+	}
+}
+
+func (s *DefaultBackupService) Backup(ctx context.Context) (models.Backup, error) {
+	backup, err := s.storage.Backup(ctx, s.dir)
+	if err != nil {
+		return models.Backup{}, err
+	}
+
+	err = s.storage.RotateBackups(s.dir, max(s.keep, 1))
+	if err != nil {
+		return backup, err
+	}
+
+	return backup, nil
+}
+
+func (s *DefaultBackupService) BackupIfDue(ctx context.Context) (bool, error) {
+	if s.interval == 0 {
+		return false, nil
+	}
+
+	backups, err := s.storage.ListBackups(s.dir)
+	if err != nil {
+		return false, err
+	}
+	if len(backups) > 0 && time.Since(backups[0].CreatedAt) < s.interval {
+		return false, nil
+	}
+
+	_, err = s.Backup(ctx)
+	return err == nil, err
+}
+
+func (s *DefaultBackupService) ListBackups() ([]models.Backup, error) {
+	return s.storage.ListBackups(s.dir)
+}
+
+func (s *DefaultBackupService) CheckIntegrity(ctx context.Context) error {
+	return s.storage.IntegrityCheck(ctx)
+}
+
+func (s *DefaultBackupService) RestoreLatestGood(ctx context.Context) (models.Backup, error) {
+	backups, err := s.storage.ListBackups(s.dir)
+	if err != nil {
+		return models.Backup{}, err
+	}
+
+	for _, backup := range backups {
+		err = s.storage.Restore(ctx, backup.Path)
+		if errors.Is(err, storage.ErrCorrupt) {
+			continue
+		}
+		if err != nil {
+			return models.Backup{}, err
+		}
+		return backup, nil
+	}
+
+	return models.Backup{}, fmt.Errorf("%w in %s", ErrNoGoodBackup, s.dir)
+}
+
+func (s *DefaultBackupService) Interval() time.Duration {
+	return s.interval
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+)
+
+func TestRestoreKeepsCopyOfIntactDatabase(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := storage.NewSQLiteStorage(filepath.Join(dir, "projects.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	projects := NewProjectService(storage.NewProjectRepository(db), nil)
+	backups := NewBackupService(db, filepath.Join(dir, "backups"), 3, time.Hour)
+
+	if _, err := backups.Backup(ctx); err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	if err := projects.CreateProject(ctx, &models.Project{Name: "proj", Path: newTestDir(t)}); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	if _, err := backups.RestoreLatestGood(ctx); err != nil {
+		t.Fatalf("RestoreLatestGood: %v", err)
+	}
+
+	listed, err := projects.ListProjects(ctx, models.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if len(listed) != 0 {
+		t.Errorf("restored database lists %d projects, want 0", len(listed))
+	}
+
+	copies, err := filepath.Glob(filepath.Join(dir, "projects.db.*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kept string
+	for _, path := range copies {
+		if strings.HasSuffix(path, ".corrupt") {
+			t.Errorf("restore of an intact database labelled its copy %s", filepath.Base(path))
+		}
+		if strings.HasSuffix(path, ".before-restore") {
+			kept = path
+		}
+	}
+	if kept == "" {
+		t.Fatalf("restore kept no copy of the database, found %q", copies)
+	}
+
+	// The copy has to hold the changes made since the backup.
+	keptDB, err := storage.NewSQLiteStorage(kept)
+	if err != nil {
+		t.Fatalf("failed to open the kept copy: %v", err)
+	}
+	defer keptDB.Close()
+	listed, err = NewProjectService(storage.NewProjectRepository(keptDB), nil).ListProjects(ctx, models.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListProjects of the kept copy: %v", err)
+	}
+	if len(listed) != 1 {
+		t.Errorf("kept copy lists %d projects, want 1", len(listed))
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+)
+
+// errDryRun rolls back the transaction of a dry-run import.
+var errDryRun = errors.New("dry run")
+
+func (s *DefaultProjectService) ExportProjects(ctx context.Context) ([]models.ProjectRecord, error) {
+	var records []models.ProjectRecord
+
+	err := s.repo.WithTx(ctx, func(repo storage.ProjectRepository) error {
+		projects, err := repo.List(ctx, models.ListOptions{IncludeArchived: true, IncludeTrashed: true})
+		if err != nil {
+			return err
+		}
+
+		for _, project := range projects {
+			history, err := repo.ListHistory(ctx, project.ID)
+			if err != nil {
+				return err
+			}
+
+			groups, err := groupPaths(ctx, repo, project.ID)
+			if err != nil {
+				return err
+			}
+
+			records = append(records, models.ProjectRecord{Project: project, History: history, Groups: groups})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// ImportCatalog applies the records in a single transaction. In a dry run the
+// transaction is rolled back, so the result only reports what would change.
+func (s *DefaultProjectService) ImportCatalog(ctx context.Context, records []models.ProjectRecord, mode models.CatalogImportMode, dryRun bool) (models.CatalogImportResult, error) {
+	if mode != models.CatalogMerge && mode != models.CatalogReplace {
+		return models.CatalogImportResult{}, fmt.Errorf("unknown import mode %q", mode)
+	}
+
+	var result models.CatalogImportResult
+	var events []Event
+
+	err := s.repo.WithTx(ctx, func(repo storage.ProjectRepository) error {
+		pinnedBefore, err := repo.ListPinned(ctx)
+		if err != nil {
+			return err
+		}
+
+		if mode == models.CatalogReplace {
+			removed, err := purgeAll(ctx, repo)
+			if err != nil {
+				return err
+			}
+			result.Removed = len(removed)
+			for _, project := range removed {
+				if project.DeletedAt.IsZero() {
+					events = append(events, ProjectDeleted{ID: project.ID})
+				}
+			}
+		}
+
+		txService := s.withRepo(repo)
+		for _, record := range records {
+			project := record.Project
+			err := s.prepareImported(&project)
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", project.Path, err)
+			}
+
+			existing, err := repo.GetByPath(ctx, project.Path)
+			if errors.Is(err, storage.ErrNotFound) {
+				err = createFromRecord(ctx, repo, &project, record)
+				if err != nil {
+					return fmt.Errorf("failed to import %s: %w", project.Path, err)
+				}
+				result.Created = append(result.Created, &project)
+				if project.DeletedAt.IsZero() {
+					events = append(events, ProjectCreated{Project: project})
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			changed, err := txService.mergeRecord(ctx, existing, &project)
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", project.Path, err)
+			}
+
+			joined, err := addToGroups(ctx, repo, project.ID, record.Groups)
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", project.Path, err)
+			}
+			changed = changed || joined
+			if changed {
+				result.Updated = append(result.Updated, &project)
+				if event := changeEvent(existing, &project); event != nil {
+					events = append(events, event)
+				}
+			} else {
+				result.Unchanged = append(result.Unchanged, &project)
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+
+		// Merged pins can move projects that are not in the file at all.
+		seen := make(map[int64]bool, len(events))
+		for _, event := range events {
+			seen[event.ProjectID()] = true
+		}
+		moved, err := pinChanges(ctx, repo, pinnedBefore)
+		if err != nil {
+			return err
+		}
+		for _, event := range moved {
+			if !seen[event.ProjectID()] {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		result.DryRun = true
+		return result, nil
+	}
+	if err != nil {
+		return models.CatalogImportResult{}, err
+	}
+
+	s.events.Publish(events...)
+	return result, nil
+}
+
+// changeEvent returns the event describing an import that changed an
+// existing project, or nil if the project stays in the trash.
+func changeEvent(existing, project *models.Project) Event {
+	wasTrashed, trashed := !existing.DeletedAt.IsZero(), !project.DeletedAt.IsZero()
+	switch {
+	case wasTrashed && trashed:
+		return nil
+	case wasTrashed:
+		return ProjectCreated{Project: *project}
+	case trashed:
+		return ProjectDeleted{ID: project.ID}
+	default:
+		return ProjectUpdated{Project: *project}
+	}
+}
+
+// createFromRecord stores a new project along with its imported history and
+// group memberships. An imported pin position is inserted among the pinned
+// projects, moving those from there on down, unless the project is in the
+// trash.
+func createFromRecord(ctx context.Context, repo storage.ProjectRepository, project *models.Project, record models.ProjectRecord) error {
+	position := project.PinnedPosition
+	project.ID = 0
+	project.PinnedPosition = 0
+	err := repo.Create(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	_, err = addToGroups(ctx, repo, project.ID, record.Groups)
+	if err != nil {
+		return err
+	}
+
+	for i := len(record.History) - 1; i >= 0; i-- {
+		revision := record.History[i]
+		_, err = repo.RecordChanges(ctx, project.ID, revision.Changes, revision.ChangedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	if position == 0 || !project.DeletedAt.IsZero() {
+		return nil
+	}
+	err = repo.Pin(ctx, project.ID, position)
+	if err != nil {
+		return err
+	}
+	pinned, err := repo.GetByID(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	*project = *pinned
+	return nil
+}
+
+// mergeRecord overwrites an existing project with the imported one and
+// reports whether anything changed. The imported history is not merged; the
+// changes themselves are recorded as a new revision instead. Group
+// memberships are merged by the caller.
+func (s *DefaultProjectService) mergeRecord(ctx context.Context, existing, project *models.Project) (bool, error) {
+	project.ID = existing.ID
+	if existing.LastOpened.After(project.LastOpened) {
+		project.LastOpened = existing.LastOpened
+	}
+
+	err := s.UpdateProject(ctx, project)
+	if err != nil {
+		return false, err
+	}
+
+	trashChanged := existing.DeletedAt.IsZero() != project.DeletedAt.IsZero()
+	if trashChanged && project.DeletedAt.IsZero() {
+		err = s.repo.Restore(ctx, project.ID)
+	} else if trashChanged {
+		err = s.repo.Delete(ctx, project.ID)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// The imported status is taken as is, without checking the transition.
+	statusChanged := existing.Status != project.Status
+	if statusChanged {
+		err = s.repo.SetStatus(ctx, project.ID, project.Status, time.Now())
+		if err != nil {
+			return false, err
+		}
+	}
+
+	pinChanged := project.DeletedAt.IsZero() && existing.PinnedPosition != project.PinnedPosition
+	if pinChanged && project.PinnedPosition > 0 {
+		err = s.repo.Pin(ctx, project.ID, project.PinnedPosition)
+	} else if pinChanged {
+		err = s.repo.Unpin(ctx, project.ID)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := s.repo.GetByID(ctx, project.ID)
+	if err != nil {
+		return false, err
+	}
+
+	changed := trashChanged || statusChanged || pinChanged || len(diffProjects(existing, updated)) > 0
+	*project = *updated
+	return changed, nil
+}
+
+// purgeAll permanently removes every project, including the trash, and
+// returns the removed projects.
+func purgeAll(ctx context.Context, repo storage.ProjectRepository) ([]models.Project, error) {
+	projects, err := repo.List(ctx, models.ListOptions{IncludeArchived: true, IncludeTrashed: true})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		err = repo.Purge(ctx, project.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return projects, nil
+}
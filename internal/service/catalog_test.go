@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestImportRenumbersPins(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	existing := &models.Project{Name: "a", Path: newTestDir(t)}
+	if err := s.CreateProject(ctx, existing); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if err := s.PinProject(ctx, existing.ID, 1); err != nil {
+		t.Fatalf("PinProject: %v", err)
+	}
+
+	records := []models.ProjectRecord{
+		{Project: models.Project{Name: "z", Path: newTestDir(t), PinnedPosition: 1}},
+		{Project: models.Project{Name: "trashed", Path: newTestDir(t), PinnedPosition: 1, DeletedAt: time.Now()}},
+	}
+	if _, err := s.ImportCatalog(ctx, records, models.CatalogMerge, false); err != nil {
+		t.Fatalf("ImportCatalog: %v", err)
+	}
+
+	pinned, err := s.ListPinned(ctx)
+	if err != nil {
+		t.Fatalf("ListPinned: %v", err)
+	}
+	var got []string
+	for i, project := range pinned {
+		if project.PinnedPosition != i+1 {
+			t.Errorf("%s is pinned at %d, want %d", project.Name, project.PinnedPosition, i+1)
+		}
+		got = append(got, project.Name)
+	}
+	if len(got) != 2 || got[0] != "z" || got[1] != "a" {
+		t.Errorf("pinned projects = %q, want [z a]", got)
+	}
+}
+
+func TestImportNormalizesRecords(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	dir := newTestDir(t)
+	existing := &models.Project{Name: "proj", Path: dir}
+	if err := s.CreateProject(ctx, existing); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	records := []models.ProjectRecord{
+		{Project: models.Project{Name: "  renamed  ", Path: dir + string(filepath.Separator)}},
+	}
+	result, err := s.ImportCatalog(ctx, records, models.CatalogMerge, false)
+	if err != nil {
+		t.Fatalf("ImportCatalog: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 1 {
+		t.Fatalf("import created %d and updated %d projects, want the existing one updated", len(result.Created), len(result.Updated))
+	}
+	if name := result.Updated[0].Name; name != "renamed" {
+		t.Errorf("imported name = %q, want it trimmed", name)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	records = []models.ProjectRecord{{Project: models.Project{Name: "elsewhere", Path: "~/src/elsewhere"}}}
+	result, err = s.ImportCatalog(ctx, records, models.CatalogMerge, true)
+	if err != nil {
+		t.Fatalf("ImportCatalog: %v", err)
+	}
+	if want := filepath.Join(home, "src", "elsewhere"); len(result.Created) != 1 || result.Created[0].Path != want {
+		t.Errorf("imported ~ path = %v, want %s", result.Created, want)
+	}
+
+	records = []models.ProjectRecord{{Project: models.Project{Name: " ", Path: dir}}}
+	if _, err := s.ImportCatalog(ctx, records, models.CatalogMerge, true); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("import of an empty name = %v, want ErrEmptyName", err)
+	}
+}
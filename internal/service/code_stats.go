@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+	"github.com/Agronomety/ProjectManager/pkg/analyzer"
+)
+
+// Languages with at least languageTagShare of the code of a project become
+// tags of the project, at most languageTagLimit of them.
+const (
+	languageTagShare = 0.2
+	languageTagLimit = 3
+)
+
+func (s *DefaultProjectService) CodeStats(ctx context.Context, id int64) (*models.CodeStats, error) {
+	stats, err := s.repo.GetCodeStats(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	return stats, err
+}
+
+func (s *DefaultProjectService) AnalyzeProject(ctx context.Context, id int64) (*models.CodeStats, error) {
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.CodeStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := analyzer.Analyze(ctx, project.Path, analyzer.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.CodeStats{ProjectID: id, Languages: languages, AnalyzedAt: time.Now()}
+	err = s.repo.SetCodeStats(ctx, stats)
+	if err != nil {
+		return nil, err
+	}
+	s.events.Publish(CodeStatsChanged{ID: id, Stats: stats})
+
+	// Only languages that were not dominant at the previous analysis are
+	// tagged, so that tags removed by hand stay removed until the code
+	// changes enough to bring them back.
+	var wasDominant []string
+	if previous != nil {
+		wasDominant = analyzer.DominantLanguages(previous.Languages, languageTagShare, languageTagLimit)
+	}
+	var newTags []string
+	for _, lang := range analyzer.DominantLanguages(languages, languageTagShare, languageTagLimit) {
+		if !hasTag(wasDominant, lang) {
+			newTags = append(newTags, lang)
+		}
+	}
+	if len(newTags) == 0 {
+		return stats, nil
+	}
+
+	// The project is read again, as it may have been edited during the
+	// analysis. The analysis is stored either way, so a failure to tag is
+	// only logged.
+	if err := s.addTags(ctx, id, newTags); err != nil {
+		log.Printf("Failed to tag %s with its languages: %v", project.Name, err)
+	}
+
+	return stats, nil
+}
+
+// addTags adds the tags a project does not have yet.
+func (s *DefaultProjectService) addTags(ctx context.Context, id int64, tags []string) error {
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	added := false
+	for _, tag := range tags {
+		if !hasTag(project.Tags, tag) {
+			project.Tags = append(project.Tags, tag)
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	return s.UpdateProject(ctx, project)
+}
+
+// hasTag reports whether tags contains tag, ignoring case like the database does.
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
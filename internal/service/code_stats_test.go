@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestAnalyzeProjectKeepsRemovedLanguageTags(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	project := &models.Project{Name: "proj", Path: newTestDir(t)}
+	if err := s.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	if _, err := s.AnalyzeProject(ctx, project.ID); err != nil {
+		t.Fatalf("AnalyzeProject: %v", err)
+	}
+	tagged, err := s.GetProject(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if !hasTag(tagged.Tags, "Go") {
+		t.Fatalf("tags after the first analysis = %q, want Go among them", tagged.Tags)
+	}
+
+	tagged.Tags = nil
+	tagged.Description = "edited by hand"
+	if err := s.UpdateProject(ctx, tagged); err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+
+	if _, err := s.AnalyzeProject(ctx, project.ID); err != nil {
+		t.Fatalf("AnalyzeProject: %v", err)
+	}
+	analyzed, err := s.GetProject(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if len(analyzed.Tags) != 0 {
+		t.Errorf("tags after the second analysis = %q, want the removed tag to stay removed", analyzed.Tags)
+	}
+	if analyzed.Description != "edited by hand" {
+		t.Errorf("description = %q, want the edit kept", analyzed.Description)
+	}
+}
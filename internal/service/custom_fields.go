@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// ErrInvalidField is returned when a custom field value does not match the field's type.
+var ErrInvalidField = errors.New("invalid field value")
+
+func (s *DefaultProjectService) CustomFields() []models.CustomFieldDefinition {
+	return s.customFields
+}
+
+// customField returns the definition of the custom field with the given
+// name, ignoring case.
+func (s *DefaultProjectService) customField(name string) (models.CustomFieldDefinition, bool) {
+	for _, field := range s.customFields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return models.CustomFieldDefinition{}, false
+}
+
+// checkCustomFields validates the custom field values of a project and
+// brings them into their stored form, recording every invalid value in
+// invalid. Values of fields that are no longer configured are kept
+// unchanged, so removing a field from the config does not lose data.
+func (s *DefaultProjectService) checkCustomFields(project *models.Project, invalid *ValidationError) {
+	if len(project.CustomFields) == 0 {
+		return
+	}
+
+	normalized := make(map[string]string, len(project.CustomFields))
+	for _, name := range slices.Sorted(maps.Keys(project.CustomFields)) {
+		value := project.CustomFields[name]
+		field, ok := s.customField(name)
+		if !ok {
+			normalized[name] = value
+			continue
+		}
+
+		value, err := field.Normalize(value)
+		if err != nil {
+			invalid.add(models.CustomFieldPrefix+field.Name, fmt.Errorf("%w: %s: %v", ErrInvalidField, field.DisplayName(), err))
+			continue
+		}
+		if value != "" {
+			normalized[field.Name] = value
+		}
+	}
+
+	project.CustomFields = normalized
+}
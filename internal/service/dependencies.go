@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+	"github.com/Agronomety/ProjectManager/pkg/manifest"
+)
+
+func (s *DefaultProjectService) Dependencies(ctx context.Context, id int64) (*models.ProjectDependencies, error) {
+	deps, err := s.repo.GetDependencies(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	return deps, err
+}
+
+func (s *DefaultProjectService) ScanDependencies(ctx context.Context, id int64) (*models.ProjectDependencies, error) {
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	found, parseErr := manifest.Parse(project.Path)
+	var manifestErr *manifest.Error
+	if parseErr != nil && !errors.As(parseErr, &manifestErr) {
+		return nil, parseErr
+	}
+
+	// The manifests that could be parsed are stored even if others could not.
+	deps := &models.ProjectDependencies{ProjectID: id, Dependencies: found, ScannedAt: time.Now()}
+	if err := s.repo.SetDependencies(ctx, deps); err != nil {
+		return nil, err
+	}
+	s.events.Publish(DependenciesChanged{ID: id, Dependencies: deps})
+
+	return deps, parseErr
+}
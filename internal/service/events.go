@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+)
+
+// Event is published by the project service after a change to the catalog
+// has been stored. It is one of ProjectCreated, ProjectUpdated,
+// ProjectDeleted, ProjectOpened, GitStatusChanged, CodeStatsChanged or
+// DependenciesChanged.
+type Event interface {
+	// ProjectID returns the ID of the project the event is about.
+	ProjectID() int64
+}
+
+// ProjectCreated reports a project added to the catalog, or brought back
+// from the trash.
+type ProjectCreated struct {
+	Project models.Project
+}
+
+// ProjectUpdated reports a stored change to a project, such as new details,
+// a new status, path or pin position, or a change of its groups.
+type ProjectUpdated struct {
+	Project models.Project
+}
+
+// ProjectDeleted reports a project moved to the trash, purged from it or,
+// by a catalog import in replace mode, removed.
+type ProjectDeleted struct {
+	ID int64
+}
+
+// ProjectOpened reports a project launched in an editor.
+type ProjectOpened struct {
+	Project models.Project
+	Editor  string
+}
+
+// GitStatusChanged reports a new git status of a project, found when it was
+// refreshed. Status is nil if the project is no longer a repository.
+type GitStatusChanged struct {
+	ID     int64
+	Status *models.GitStatus
+}
+
+// CodeStatsChanged reports a new analysis of the source files of a project.
+type CodeStatsChanged struct {
+	ID    int64
+	Stats *models.CodeStats
+}
+
+// DependenciesChanged reports a new scan of the manifests of a project.
+type DependenciesChanged struct {
+	ID           int64
+	Dependencies *models.ProjectDependencies
+}
+
+func (e ProjectCreated) ProjectID() int64      { return e.Project.ID }
+func (e ProjectUpdated) ProjectID() int64      { return e.Project.ID }
+func (e ProjectDeleted) ProjectID() int64      { return e.ID }
+func (e ProjectOpened) ProjectID() int64       { return e.Project.ID }
+func (e GitStatusChanged) ProjectID() int64    { return e.ID }
+func (e CodeStatsChanged) ProjectID() int64    { return e.ID }
+func (e DependenciesChanged) ProjectID() int64 { return e.ID }
+
+// EventBus delivers events to subscribed handlers. Handlers run on the
+// goroutine that publishes, one after the other, so they should return
+// quickly.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[int]func(Event)
+	nextID   int
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[int]func(Event))}
+}
+
+// Subscribe registers a handler for all events and returns a function that
+// removes it again.
+func (b *EventBus) Subscribe(handler func(Event)) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+// Publish delivers events to every handler subscribed at the time of the
+// call. Publishing on a nil bus does nothing.
+func (b *EventBus) Publish(events ...Event) {
+	if b == nil {
+		return
+	}
+
+	// Copy the handlers, so that they can subscribe and unsubscribe themselves.
+	b.mu.RLock()
+	handlers := make([]func(Event), 0, len(b.handlers))
+	for _, handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+func (s *DefaultProjectService) Subscribe(handler func(Event)) (unsubscribe func()) {
+	return s.events.Subscribe(handler)
+}
+
+// publishStored publishes an event carrying the stored version of a project,
+// built by newEvent. The change it reports has already succeeded, so a
+// failure to load the project is only logged.
+func (s *DefaultProjectService) publishStored(ctx context.Context, id int64, newEvent func(models.Project) Event) {
+	if s.events == nil {
+		return
+	}
+
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to load project %d for change notification: %v", id, err)
+		return
+	}
+	s.events.Publish(newEvent(*project))
+}
+
+func created(project models.Project) Event { return ProjectCreated{Project: project} }
+func updated(project models.Project) Event { return ProjectUpdated{Project: project} }
+
+// pinChanges returns update events for the projects whose pin position
+// differs from the one they had in before, the pinned list read earlier.
+func pinChanges(ctx context.Context, repo storage.ProjectRepository, before []models.Project) ([]Event, error) {
+	after, err := repo.ListPinned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[int64]int, len(before))
+	for _, project := range before {
+		positions[project.ID] = project.PinnedPosition
+	}
+
+	var events []Event
+	for _, project := range after {
+		if positions[project.ID] != project.PinnedPosition {
+			events = append(events, ProjectUpdated{Project: project})
+		}
+		delete(positions, project.ID)
+	}
+
+	// Projects left in positions have been unpinned, or removed altogether.
+	for id := range positions {
+		project, err := repo.GetByID(ctx, id)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if project.DeletedAt.IsZero() {
+			events = append(events, ProjectUpdated{Project: *project})
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// Scoring constants for fuzzyMatch, modelled after fzf: every matched
+// character earns a base score, characters that continue a run or start
+// a word earn bonuses, and gaps between matched characters cost points.
+const (
+	scoreMatch        = 16
+	bonusConsecutive  = 8
+	bonusBoundary     = 8
+	bonusFirstChar    = 12
+	penaltyGapStart   = 3
+	penaltyGapExtend  = 1
+	frecencyLookback  = 90 * 24 * time.Hour
+	defaultFuzzyLimit = 50
+)
+
+// Weights applied to the fuzzy score of the different project attributes.
+const (
+	weightName = 1.0
+	weightTag  = 0.8
+	weightPath = 0.6
+)
+
+// fuzzyMatch reports whether all runes of pattern occur in text in order
+// and, if so, how good the match is together with the matched positions.
+// Matching is case-insensitive.
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(p) == 0 || len(p) > len(t) {
+		return 0, nil, false
+	}
+
+	// Find the first window that contains the pattern, then walk back from
+	// its end to shrink it to the shortest match ending there.
+	pi := 0
+	end := -1
+	for ti := 0; ti < len(lower); ti++ {
+		if lower[ti] == p[pi] {
+			pi++
+			if pi == len(p) {
+				end = ti
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, len(p))
+	pi = len(p) - 1
+	for ti := end; ti >= 0 && pi >= 0; ti-- {
+		if lower[ti] == p[pi] {
+			positions[pi] = ti
+			pi--
+		}
+	}
+
+	score := 0
+	for i, pos := range positions {
+		score += scoreMatch
+		if isWordStart(t, pos) {
+			score += bonusBoundary
+			if i == 0 {
+				score += bonusFirstChar
+			}
+		}
+		if i > 0 {
+			gap := pos - positions[i-1] - 1
+			if gap == 0 {
+				score += bonusConsecutive
+			} else {
+				score -= penaltyGapStart + (gap-1)*penaltyGapExtend
+			}
+		}
+	}
+
+	return score, positions, true
+}
+
+// isWordStart reports whether the rune at pos starts a word: it is the first
+// rune, follows a separator, or is an upper case letter after a lower case one.
+func isWordStart(text []rune, pos int) bool {
+	if pos == 0 {
+		return true
+	}
+
+	prev, cur := text[pos-1], text[pos]
+	switch {
+	case strings.ContainsRune("/\\-_. ", prev):
+		return true
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return true
+	case !unicode.IsDigit(prev) && unicode.IsDigit(cur):
+		return true
+	}
+
+	return false
+}
+
+// frecency scores how often and how recently a project was opened, in the
+// style of zoxide: recent launches count more than old ones.
+func frecency(launches []time.Time, now time.Time) float64 {
+	score := 0.0
+	for _, launchedAt := range launches {
+		age := now.Sub(launchedAt)
+		switch {
+		case age < time.Hour:
+			score += 4
+		case age < 24*time.Hour:
+			score += 2
+		case age < 7*24*time.Hour:
+			score += 0.5
+		default:
+			score += 0.25
+		}
+	}
+	return score
+}
+
+// FuzzyFindProjects matches the pattern against project names, tags and
+// paths and ranks the matches by match quality boosted by frecency. An
+// empty pattern returns the projects ordered by frecency alone.
+func (s *DefaultProjectService) FuzzyFindProjects(ctx context.Context, pattern string, limit int) ([]models.FuzzyMatch, error) {
+	if limit <= 0 {
+		limit = defaultFuzzyLimit
+	}
+
+	projects, err := s.repo.List(ctx, models.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	launches, err := s.repo.ListLaunches(ctx, now.Add(-frecencyLookback))
+	if err != nil {
+		return nil, err
+	}
+
+	launchTimes := make(map[int64][]time.Time)
+	for _, launch := range launches {
+		launchTimes[launch.ProjectID] = append(launchTimes[launch.ProjectID], launch.LaunchedAt)
+	}
+
+	pattern = strings.Join(strings.Fields(pattern), "")
+
+	var matches []models.FuzzyMatch
+	for _, project := range projects {
+		// Projects opened before launches were recorded still have LastOpened.
+		times := launchTimes[project.ID]
+		if len(times) == 0 && !project.LastOpened.IsZero() {
+			times = []time.Time{project.LastOpened}
+		}
+		boost := 1 + math.Log1p(frecency(times, now))
+
+		if pattern == "" {
+			matches = append(matches, models.FuzzyMatch{Project: project, Score: boost})
+			continue
+		}
+
+		match := models.FuzzyMatch{Project: project}
+		best := math.Inf(-1)
+
+		if score, positions, ok := fuzzyMatch(pattern, project.Name); ok {
+			best = float64(score) * weightName
+			match.NamePositions = positions
+		}
+		for _, tag := range project.Tags {
+			if score, _, ok := fuzzyMatch(pattern, tag); ok && float64(score)*weightTag > best {
+				best = float64(score) * weightTag
+				match.NamePositions = nil
+			}
+		}
+		if score, _, ok := fuzzyMatch(pattern, filepath.ToSlash(project.Path)); ok && float64(score)*weightPath > best {
+			best = float64(score) * weightPath
+			match.NamePositions = nil
+		}
+
+		if math.IsInf(best, -1) {
+			continue
+		}
+
+		// Long gaps can push a valid match below zero; keep it as a weak match.
+		match.Score = math.Max(best, 1) * boost
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return strings.ToLower(matches[i].Project.Name) < strings.ToLower(matches[j].Project.Name)
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
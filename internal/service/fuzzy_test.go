@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		score         int
+		positions     []int
+		ok            bool
+	}{
+		{"abc", "abc", 84, []int{0, 1, 2}, true},
+		{"ABC", "xabc", 64, []int{1, 2, 3}, true},
+		{"pm", "ProjectManager", 52, []int{0, 7}, true},
+		{"ab", "a-a-b", 57, []int{2, 4}, true},
+		{"é", "Café", 16, []int{3}, true},
+		{"abd", "abc", 0, nil, false},
+		{"abcd", "abc", 0, nil, false},
+		{"", "abc", 0, nil, false},
+	}
+	for _, test := range tests {
+		score, positions, ok := fuzzyMatch(test.pattern, test.text)
+		if ok != test.ok || score != test.score || !reflect.DeepEqual(positions, test.positions) {
+			t.Errorf("fuzzyMatch(%q, %q) = %d, %v, %v; want %d, %v, %v",
+				test.pattern, test.text, score, positions, ok, test.score, test.positions, test.ok)
+		}
+	}
+}
+
+func TestFuzzyMatchPrefersWordStarts(t *testing.T) {
+	boundary, _, _ := fuzzyMatch("pm", "project-manager")
+	inner, _, _ := fuzzyMatch("pm", "upmost")
+	if boundary <= inner {
+		t.Errorf("word start match scored %d, not above inner match %d", boundary, inner)
+	}
+}
+
+func TestIsWordStart(t *testing.T) {
+	tests := []struct {
+		text string
+		pos  int
+		want bool
+	}{
+		{"abc", 0, true},
+		{"abc", 1, false},
+		{"foo_bar", 4, true},
+		{"foo/bar", 4, true},
+		{"foo.bar", 4, true},
+		{"fooBar", 3, true},
+		{"FOO", 1, false},
+		{"v2", 1, true},
+		{"12", 1, false},
+	}
+	for _, test := range tests {
+		if got := isWordStart([]rune(test.text), test.pos); got != test.want {
+			t.Errorf("isWordStart(%q, %d) = %v, want %v", test.text, test.pos, got, test.want)
+		}
+	}
+}
+
+func TestFrecency(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		ages []time.Duration
+		want float64
+	}{
+		{nil, 0},
+		{[]time.Duration{30 * time.Minute}, 4},
+		{[]time.Duration{2 * time.Hour}, 2},
+		{[]time.Duration{3 * 24 * time.Hour}, 0.5},
+		{[]time.Duration{30 * 24 * time.Hour}, 0.25},
+		{[]time.Duration{time.Minute, time.Minute, 2 * time.Hour}, 10},
+	}
+	for _, test := range tests {
+		var launches []time.Time
+		for _, age := range test.ages {
+			launches = append(launches, now.Add(-age))
+		}
+		if got := frecency(launches, now); got != test.want {
+			t.Errorf("frecency(%v) = %v, want %v", test.ages, got, test.want)
+		}
+	}
+}
+
+func TestFuzzyFindProjectsRanksByFrecency(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	client := &models.Project{Name: "api-client", Path: newTestDir(t)}
+	server := &models.Project{Name: "api-server", Path: newTestDir(t)}
+	other := &models.Project{Name: "website", Path: newTestDir(t)}
+	for _, project := range []*models.Project{client, server, other} {
+		if err := s.CreateProject(ctx, project); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+	}
+
+	names := func(pattern string) []string {
+		t.Helper()
+		matches, err := s.FuzzyFindProjects(ctx, pattern, 0)
+		if err != nil {
+			t.Fatalf("FuzzyFindProjects(%q): %v", pattern, err)
+		}
+		var names []string
+		for _, match := range matches {
+			names = append(names, match.Project.Name)
+		}
+		return names
+	}
+
+	if got, want := names("api"), []string{"api-client", "api-server"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("before launches got %v, want %v", got, want)
+	}
+
+	if err := s.RecordLaunch(ctx, server.ID, "code"); err != nil {
+		t.Fatalf("RecordLaunch: %v", err)
+	}
+	if got, want := names("api"), []string{"api-server", "api-client"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after launching api-server got %v, want %v", got, want)
+	}
+	if got, want := names("aps"), []string{"api-server"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("three keystrokes got %v, want %v", got, want)
+	}
+}
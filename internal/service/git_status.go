@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+	"github.com/Agronomety/ProjectManager/pkg/git"
+)
+
+func (s *DefaultProjectService) GitStatus(ctx context.Context, id int64) (*models.GitStatus, error) {
+	status, err := s.repo.GetGitStatus(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	return status, err
+}
+
+func (s *DefaultProjectService) GitStatuses(ctx context.Context) (map[int64]models.GitStatus, error) {
+	statuses, err := s.repo.ListGitStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]models.GitStatus, len(statuses))
+	for _, status := range statuses {
+		byID[status.ProjectID] = status
+	}
+	return byID, nil
+}
+
+func (s *DefaultProjectService) RefreshGitStatus(ctx context.Context, id int64) (*models.GitStatus, error) {
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := s.GitStatus(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !git.IsRepository(project.Path) {
+		if cached == nil {
+			return nil, nil
+		}
+		err = s.repo.DeleteGitStatus(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		s.events.Publish(GitStatusChanged{ID: id})
+		return nil, nil
+	}
+
+	status, err := git.Inspect(ctx, project.Path)
+	if err != nil {
+		return nil, err
+	}
+	status.ProjectID = id
+	status.CheckedAt = time.Now()
+
+	err = s.repo.SetGitStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameGitStatus(cached, status) {
+		s.events.Publish(GitStatusChanged{ID: id, Status: status})
+	}
+	return status, nil
+}
+
+func (s *DefaultProjectService) RefreshGitStatuses(ctx context.Context) (int, error) {
+	projects, err := s.repo.List(ctx, models.ListOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	repositories := 0
+	for _, project := range projects {
+		if ctx.Err() != nil {
+			return repositories, ctx.Err()
+		}
+
+		status, err := s.RefreshGitStatus(ctx, project.ID)
+		if err != nil {
+			// One broken repository should not hold up the others.
+			log.Printf("Failed to refresh git status of %s: %v", project.Name, err)
+			continue
+		}
+		if status != nil {
+			repositories++
+		}
+	}
+	return repositories, nil
+}
+
+// sameGitStatus reports whether two statuses differ only in when they were
+// checked. Either may be nil.
+func sameGitStatus(a, b *models.GitStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ProjectID == b.ProjectID && a.Branch == b.Branch && a.Upstream == b.Upstream &&
+		a.Ahead == b.Ahead && a.Behind == b.Behind &&
+		a.Staged == b.Staged && a.Modified == b.Modified && a.Untracked == b.Untracked && a.Conflicted == b.Conflicted &&
+		a.LastCommitAt.Equal(b.LastCommitAt) && a.LastCommitAuthor == b.LastCommitAuthor &&
+		maps.Equal(a.Remotes, b.Remotes)
+}
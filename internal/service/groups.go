@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+)
+
+func (s *DefaultProjectService) ListGroups(ctx context.Context) ([]models.Group, error) {
+	return s.repo.ListGroups(ctx)
+}
+
+func (s *DefaultProjectService) CreateGroup(ctx context.Context, name string, parentID int64) (*models.Group, error) {
+	group := &models.Group{Name: name, ParentID: parentID}
+	err := s.repo.CreateGroup(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *DefaultProjectService) RenameGroup(ctx context.Context, id int64, name string) error {
+	return s.repo.RenameGroup(ctx, id, name)
+}
+
+func (s *DefaultProjectService) MoveGroup(ctx context.Context, id, parentID int64) error {
+	return s.repo.MoveGroup(ctx, id, parentID)
+}
+
+func (s *DefaultProjectService) DeleteGroup(ctx context.Context, id int64) error {
+	return s.repo.DeleteGroup(ctx, id)
+}
+
+func (s *DefaultProjectService) ProjectGroups(ctx context.Context, projectID int64) ([]models.Group, error) {
+	return s.repo.ProjectGroups(ctx, projectID)
+}
+
+func (s *DefaultProjectService) AddProjectToGroup(ctx context.Context, projectID, groupID int64) error {
+	err := s.repo.AddProjectToGroup(ctx, projectID, groupID)
+	if err != nil {
+		return err
+	}
+
+	s.publishStored(ctx, projectID, updated)
+	return nil
+}
+
+func (s *DefaultProjectService) RemoveProjectFromGroup(ctx context.Context, projectID, groupID int64) error {
+	err := s.repo.RemoveProjectFromGroup(ctx, projectID, groupID)
+	if err != nil {
+		return err
+	}
+
+	s.publishStored(ctx, projectID, updated)
+	return nil
+}
+
+// groupPaths returns the paths of the groups a project belongs to.
+func groupPaths(ctx context.Context, repo storage.ProjectRepository, projectID int64) ([]string, error) {
+	groups, err := repo.ProjectGroups(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, group := range groups {
+		paths = append(paths, group.Path)
+	}
+	return paths, nil
+}
+
+// addToGroups adds a project to the groups with the given paths, creating
+// missing groups, and reports whether the project joined any group.
+func addToGroups(ctx context.Context, repo storage.ProjectRepository, projectID int64, paths []string) (bool, error) {
+	if len(paths) == 0 {
+		return false, nil
+	}
+
+	before, err := repo.ProjectGroups(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+	member := make(map[int64]bool, len(before))
+	for _, group := range before {
+		member[group.ID] = true
+	}
+
+	added := false
+	for _, path := range paths {
+		group, err := repo.EnsureGroupPath(ctx, path)
+		if err != nil {
+			return false, err
+		}
+		if member[group.ID] {
+			continue
+		}
+
+		err = repo.AddProjectToGroup(ctx, projectID, group.ID)
+		if err != nil {
+			return false, err
+		}
+		member[group.ID] = true
+		added = true
+	}
+
+	return added, nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/pkg/git"
+	"github.com/Agronomety/ProjectManager/pkg/utils"
+)
+
+// ErrNoFix is returned when a finding has no automatic fix.
+var ErrNoFix = errors.New("no automatic fix for this issue")
+
+// HealthService looks for broken and neglected entries in the catalog.
+type HealthService interface {
+	// Check goes through every project that is neither archived nor in the
+	// trash and reports its problems.
+	Check(ctx context.Context, opts models.HealthOptions) (models.HealthReport, error)
+	// Fix applies the automatic fix of a finding, or returns ErrNoFix if
+	// it has none.
+	Fix(ctx context.Context, finding models.HealthFinding) error
+}
+
+type DefaultHealthService struct {
+	projectService ProjectService
+}
+
+// NewHealthService returns a health service that reads and fixes projects
+// through projectService, so that fixes are validated and published like
+// any other change.
+func NewHealthService(projectService ProjectService) HealthService {
+	return &DefaultHealthService{projectService: projectService}
+}
+
+func (s *DefaultHealthService) Check(ctx context.Context, opts models.HealthOptions) (models.HealthReport, error) {
+	report := models.HealthReport{CheckedAt: time.Now()}
+
+	projects, err := s.projectService.ListProjects(ctx, models.ListOptions{SortBy: models.SortByName})
+	if err != nil {
+		return report, err
+	}
+	report.Checked = len(projects)
+
+	missing, err := s.projectService.FindMissingProjects(ctx, opts.SearchRoots)
+	if err != nil {
+		return report, err
+	}
+	candidates := make(map[int64][]models.RelocationCandidate, len(missing))
+	for _, m := range missing {
+		candidates[m.Project.ID] = m.Candidates
+	}
+
+	// Projects by the normalised URLs of their remotes.
+	byRemote := make(map[string][]models.Project)
+	for _, project := range projects {
+		moved, isMissing := candidates[project.ID]
+		if isMissing {
+			report.Findings = append(report.Findings, missingPathFinding(project, moved))
+		} else {
+			if finding, ok := readmeFinding(project); ok {
+				report.Findings = append(report.Findings, finding)
+			}
+			for _, url := range remoteURLs(project.Path) {
+				byRemote[url] = append(byRemote[url], project)
+			}
+		}
+
+		if strings.TrimSpace(project.Description) == "" {
+			report.Findings = append(report.Findings, models.HealthFinding{
+				Project: project,
+				Issue:   models.IssueNoDescription,
+				Message: "no description",
+			})
+		}
+
+		if len(project.Tags) == 0 {
+			report.Findings = append(report.Findings, models.HealthFinding{
+				Project: project,
+				Issue:   models.IssueNoTags,
+				Message: "no tags",
+			})
+		}
+
+		if finding, ok := staleFinding(project, opts.StaleAfter, report.CheckedAt); ok {
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	for url, sharing := range byRemote {
+		if len(sharing) < 2 {
+			continue
+		}
+		for _, project := range sharing {
+			var others []string
+			for _, other := range sharing {
+				if other.ID != project.ID {
+					others = append(others, other.Name)
+				}
+			}
+			report.Findings = append(report.Findings, models.HealthFinding{
+				Project: project,
+				Issue:   models.IssueDuplicateRemote,
+				Message: fmt.Sprintf("remote %s is also used by %s", url, strings.Join(others, ", ")),
+			})
+		}
+	}
+
+	// The projects are listed by name already; this only moves the remote
+	// findings next to the other findings of their project.
+	slices.SortStableFunc(report.Findings, func(a, b models.HealthFinding) int {
+		return strings.Compare(strings.ToLower(a.Project.Name), strings.ToLower(b.Project.Name))
+	})
+
+	return report, nil
+}
+
+func (s *DefaultHealthService) Fix(ctx context.Context, finding models.HealthFinding) error {
+	switch finding.Fix {
+	case models.FixRelocate:
+		return s.projectService.RelocateProject(ctx, finding.Project.ID, finding.Target)
+
+	case models.FixDetectReadme:
+		project, err := s.projectService.GetProject(ctx, finding.Project.ID)
+		if err != nil {
+			return err
+		}
+		project.ReadmePath = finding.Target
+		return s.projectService.UpdateProject(ctx, project)
+
+	case models.FixArchive:
+		return s.projectService.ChangeStatus(ctx, finding.Project.ID, models.StatusArchived)
+	}
+
+	return ErrNoFix
+}
+
+// missingPathFinding reports a project whose folder is gone, offering to
+// move it to the best of the candidate locations.
+func missingPathFinding(project models.Project, candidates []models.RelocationCandidate) models.HealthFinding {
+	finding := models.HealthFinding{
+		Project: project,
+		Issue:   models.IssueMissingPath,
+		Message: fmt.Sprintf("%s no longer exists", project.Path),
+	}
+	if len(candidates) > 0 {
+		finding.Fix = models.FixRelocate
+		finding.Target = candidates[0].Path
+		finding.Message += fmt.Sprintf(", it may have moved to %s", finding.Target)
+	}
+	return finding
+}
+
+// readmeFinding reports a project without a README link, or whose README
+// file is gone, offering to link the README found in the project folder.
+func readmeFinding(project models.Project) (models.HealthFinding, bool) {
+	finding := models.HealthFinding{Project: project}
+	if project.ReadmePath == "" {
+		finding.Issue = models.IssueMissingReadme
+		finding.Message = "no README linked"
+	} else if _, err := os.Stat(project.ReadmePath); err != nil {
+		finding.Issue = models.IssueDanglingReadme
+		finding.Message = fmt.Sprintf("README %s no longer exists", project.ReadmePath)
+	} else {
+		return finding, false
+	}
+
+	if readme, err := utils.FindReadmeFile(project.Path); err == nil && readme != project.ReadmePath {
+		finding.Fix = models.FixDetectReadme
+		finding.Target = readme
+		finding.Message += fmt.Sprintf(", found %s", readme)
+	}
+	return finding, true
+}
+
+// staleFinding reports a project not opened for longer than staleAfter,
+// offering to archive it. Projects that were never opened count from the
+// day they were added.
+func staleFinding(project models.Project, staleAfter time.Duration, now time.Time) (models.HealthFinding, bool) {
+	if staleAfter <= 0 {
+		return models.HealthFinding{}, false
+	}
+
+	last := project.LastOpened
+	if project.CreatedAt.After(last) {
+		last = project.CreatedAt
+	}
+	if last.IsZero() || now.Sub(last) <= staleAfter {
+		return models.HealthFinding{}, false
+	}
+
+	return models.HealthFinding{
+		Project: project,
+		Issue:   models.IssueStale,
+		Message: fmt.Sprintf("not opened in %d days", int(now.Sub(last).Hours()/24)),
+		Fix:     models.FixArchive,
+	}, true
+}
+
+// remoteURLs returns the distinct normalised remote URLs of the repository
+// at dir, if it is one.
+func remoteURLs(dir string) []string {
+	if !git.IsRepository(dir) {
+		return nil
+	}
+
+	remotes, err := git.Remotes(dir)
+	if err != nil {
+		log.Printf("Failed to read git remotes: %v", err)
+		return nil
+	}
+
+	var urls []string
+	for _, url := range remotes {
+		url = git.NormalizeRemoteURL(url)
+		if !slices.Contains(urls, url) {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
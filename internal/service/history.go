@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func (s *DefaultProjectService) ProjectHistory(ctx context.Context, id int64) ([]models.ProjectRevision, error) {
+	return s.repo.ListHistory(ctx, id)
+}
+
+func (s *DefaultProjectService) RevertProject(ctx context.Context, id int64, revision int) error {
+	if revision < 0 {
+		return fmt.Errorf("invalid revision %d", revision)
+	}
+
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	history, err := s.repo.ListHistory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if revision > 0 && !hasRevision(history, revision) {
+		return fmt.Errorf("project %d has no revision %d", id, revision)
+	}
+
+	// Walking from the newest revision back to the target, the old value of
+	// each change is what the field held before it; the oldest one wins.
+	values := projectFields(project)
+	for _, rev := range history {
+		if rev.Revision <= revision {
+			break
+		}
+		for _, change := range rev.Changes {
+			values[change.Field] = change.OldValue
+		}
+	}
+
+	project.Name = values[models.FieldName]
+	project.Description = values[models.FieldDescription]
+	project.ReadmePath = values[models.FieldReadmePath]
+	project.Icon = values[models.FieldIcon]
+	project.Tags, err = splitTags(values[models.FieldTags])
+	if err != nil {
+		return fmt.Errorf("failed to read tags from history: %v", err)
+	}
+	project.CustomFields = make(map[string]string)
+	for field, value := range values {
+		if name, ok := strings.CutPrefix(field, models.CustomFieldPrefix); ok && value != "" {
+			project.CustomFields[name] = value
+		}
+	}
+
+	return s.UpdateProject(ctx, project)
+}
+
+func hasRevision(history []models.ProjectRevision, revision int) bool {
+	for _, rev := range history {
+		if rev.Revision == revision {
+			return true
+		}
+	}
+	return false
+}
+
+// projectFields returns the tracked fields of a project as history values.
+func projectFields(project *models.Project) map[string]string {
+	tags := append([]string(nil), project.Tags...)
+	sort.Strings(tags)
+
+	values := map[string]string{
+		models.FieldName:        project.Name,
+		models.FieldDescription: project.Description,
+		models.FieldReadmePath:  project.ReadmePath,
+		models.FieldIcon:        project.Icon,
+		models.FieldTags:        joinTags(tags),
+	}
+	for name, value := range project.CustomFields {
+		values[models.CustomFieldPrefix+name] = value
+	}
+
+	return values
+}
+
+// diffProjects lists the tracked fields that differ between two versions of a project.
+func diffProjects(before, after *models.Project) []models.FieldChange {
+	oldValues := projectFields(before)
+	newValues := projectFields(after)
+
+	fields := []string{
+		models.FieldName,
+		models.FieldDescription,
+		models.FieldReadmePath,
+		models.FieldIcon,
+		models.FieldTags,
+	}
+
+	// Custom fields follow in name order; a field missing on one side
+	// compares as empty.
+	var custom []string
+	for field := range oldValues {
+		if strings.HasPrefix(field, models.CustomFieldPrefix) {
+			custom = append(custom, field)
+		}
+	}
+	for field := range newValues {
+		if _, ok := oldValues[field]; !ok && strings.HasPrefix(field, models.CustomFieldPrefix) {
+			custom = append(custom, field)
+		}
+	}
+	sort.Strings(custom)
+
+	var changes []models.FieldChange
+	for _, field := range append(fields, custom...) {
+		if oldValues[field] != newValues[field] {
+			changes = append(changes, models.FieldChange{
+				Field:    field,
+				OldValue: oldValues[field],
+				NewValue: newValues[field],
+			})
+		}
+	}
+
+	return changes
+}
+
+// joinTags encodes tag names as a history value. Tags may contain commas,
+// so they are stored as a JSON array.
+func joinTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	// Marshalling strings cannot fail.
+	value, _ := json.Marshal(tags)
+	return string(value)
+}
+
+// splitTags decodes a history value written by joinTags.
+func splitTags(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var tags []string
+	err := json.Unmarshal([]byte(value), &tags)
+	return tags, err
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+)
+
+func newTestService(t *testing.T) ProjectService {
+	t.Helper()
+	db, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "projects.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewProjectService(storage.NewProjectRepository(db), nil)
+}
+
+// newTestDir returns a directory that passes the project path validation.
+func newTestDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRevertKeepsTagsWithCommas(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	project := &models.Project{Name: "proj", Path: newTestDir(t), Tags: []string{"client, acme", "go"}}
+	if err := s.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	project.Tags = []string{"rust"}
+	if err := s.UpdateProject(ctx, project); err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+
+	if err := s.RevertProject(ctx, project.ID, 0); err != nil {
+		t.Fatalf("RevertProject: %v", err)
+	}
+	reverted, err := s.GetProject(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	tags := slices.Clone(reverted.Tags)
+	slices.Sort(tags)
+	if want := []string{"client, acme", "go"}; !slices.Equal(tags, want) {
+		t.Errorf("tags after revert = %q, want %q", tags, want)
+	}
+}
+
+func TestSplitTags(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{`["client, acme","go"]`, []string{"client, acme", "go"}},
+	}
+	for _, test := range tests {
+		got, err := splitTags(test.value)
+		if err != nil || !slices.Equal(got, test.want) {
+			t.Errorf("splitTags(%q) = %q, %v, want %q", test.value, got, err, test.want)
+		}
+	}
+	if _, err := splitTags("api, go"); err == nil {
+		t.Error("splitTags accepted a value that is not a JSON array")
+	}
+	if got, _ := splitTags(joinTags([]string{"a, b", "c"})); !slices.Equal(got, []string{"a, b", "c"}) {
+		t.Errorf("joinTags does not round trip: %q", got)
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+	"github.com/Agronomety/ProjectManager/pkg/git"
+	"github.com/Agronomety/ProjectManager/pkg/utils"
+)
+
+// ErrInvalidPath is returned when a project path does not point to a usable directory.
+var ErrInvalidPath = errors.New("invalid project path")
+
+func (s *DefaultProjectService) RelocateProject(ctx context.Context, id int64, newPath string) error {
+	newPath, err := NormalizePath(newPath)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPath, err)
+	}
+
+	err = utils.ValidateProjectPath(newPath)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPath, err)
+	}
+
+	moved := false
+	err = s.repo.WithTx(ctx, func(repo storage.ProjectRepository) error {
+		project, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if project.Path == newPath {
+			return nil
+		}
+		moved = true
+
+		err = repo.Relocate(ctx, id, newPath)
+		if err != nil {
+			return err
+		}
+
+		if commit := gitRootCommit(newPath); commit != "" {
+			err = repo.SetGitRootCommit(ctx, id, commit)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = repo.RecordChanges(ctx, id, []models.FieldChange{{
+			Field:    models.FieldPath,
+			OldValue: project.Path,
+			NewValue: newPath,
+		}}, time.Now())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if moved {
+		s.publishStored(ctx, id, updated)
+	}
+	return nil
+}
+
+// FindMissingProjects returns the projects whose path no longer exists,
+// with candidates for their new location found under searchRoots. Along the
+// way it remembers the git root commit of projects that are still in place,
+// so that they can be recognised if they move later.
+func (s *DefaultProjectService) FindMissingProjects(ctx context.Context, searchRoots []string) ([]models.MissingProject, error) {
+	projects, err := s.repo.List(ctx, models.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []models.MissingProject
+	registered := make(map[string]bool, len(projects))
+	for _, project := range projects {
+		registered[project.Path] = true
+
+		if _, err := os.Stat(project.Path); errors.Is(err, os.ErrNotExist) {
+			missing = append(missing, models.MissingProject{Project: project})
+			continue
+		}
+
+		if project.GitRootCommit == "" {
+			if commit := gitRootCommit(project.Path); commit != "" {
+				err = s.repo.SetGitRootCommit(ctx, project.ID, commit)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	var roots []string
+	for _, root := range searchRoots {
+		if info, err := os.Stat(root); err == nil && info.IsDir() {
+			roots = append(roots, root)
+		}
+	}
+
+	directories, err := utils.FindProjectRoots(roots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for moved projects: %v", err)
+	}
+
+	// Root commits are only computed when a missing project has one to
+	// compare against, and at most once per directory.
+	commits := make(map[string]string)
+	commitOf := func(dir string) string {
+		commit, ok := commits[dir]
+		if !ok {
+			commit = gitRootCommit(dir)
+			commits[dir] = commit
+		}
+		return commit
+	}
+
+	for i := range missing {
+		project := missing[i].Project
+		var byName []models.RelocationCandidate
+		for _, dir := range directories {
+			if registered[dir] {
+				continue
+			}
+
+			if project.GitRootCommit != "" && commitOf(dir) == project.GitRootCommit {
+				missing[i].Candidates = append(missing[i].Candidates, models.RelocationCandidate{Path: dir, MatchedBy: models.MatchedByRootCommit})
+			} else if strings.EqualFold(filepath.Base(dir), filepath.Base(project.Path)) {
+				byName = append(byName, models.RelocationCandidate{Path: dir, MatchedBy: models.MatchedByName})
+			}
+		}
+		missing[i].Candidates = append(missing[i].Candidates, byName...)
+	}
+
+	return missing, nil
+}
+
+// gitRootCommit returns the root commit of the repository at dir, or an
+// empty string if dir is not a git repository or has no commits.
+func gitRootCommit(dir string) string {
+	if !git.IsRepository(dir) {
+		return ""
+	}
+
+	commit, err := git.RootCommit(dir)
+	if err != nil {
+		log.Printf("Failed to read git root commit: %v", err)
+		return ""
+	}
+
+	return commit
+}
@@ -1,64 +1,484 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/query"
 	"github.com/Agronomety/ProjectManager/internal/storage"
 )
 
 type ProjectService interface {
-	CreateProject(project *models.Project) error
-	UpdateProject(project *models.Project) error
-	DeleteProject(id int64) error
-	GetProject(id int64) (*models.Project, error)
-	ListProjects() ([]models.Project, error)
-	SearchProjects(query string) ([]models.Project, error)
+	// CreateProject registers a project after expanding ~ in its path,
+	// making the path absolute and resolving symbolic links. Invalid fields
+	// are reported together in a *ValidationError, and a path that belongs
+	// to a trashed project in a *TrashedDuplicateError.
+	CreateProject(ctx context.Context, project *models.Project) error
+	// CheckProject normalises and validates a new project like CreateProject
+	// without storing it, and returns the registered projects it would be
+	// nested in or would contain.
+	CheckProject(ctx context.Context, project *models.Project) ([]models.Project, error)
+	// ImportProjects registers all given projects atomically: either all of
+	// them are stored or, if one fails, none are. Projects whose path is
+	// already registered have their metadata refreshed instead, and are
+	// restored if they are in the trash.
+	ImportProjects(ctx context.Context, projects []*models.Project) (models.ImportResult, error)
+	UpdateProject(ctx context.Context, project *models.Project) error
+	// RelocateProject changes the path of a project after checking that the
+	// new path is an existing directory. Invalid paths return ErrInvalidPath.
+	RelocateProject(ctx context.Context, id int64, newPath string) error
+	// FindMissingProjects returns the projects whose path no longer exists,
+	// with possible new locations found under searchRoots.
+	FindMissingProjects(ctx context.Context, searchRoots []string) ([]models.MissingProject, error)
+	// ExportProjects returns every project, including the trash, with its history.
+	ExportProjects(ctx context.Context) ([]models.ProjectRecord, error)
+	// ImportCatalog stores exported projects according to mode. With dryRun
+	// set nothing is written, but the result reports what would have changed.
+	ImportCatalog(ctx context.Context, records []models.ProjectRecord, mode models.CatalogImportMode, dryRun bool) (models.CatalogImportResult, error)
+	// CustomFields returns the definitions of the configured custom fields.
+	CustomFields() []models.CustomFieldDefinition
+	// ChangeStatus moves a project to another status if the transition is
+	// allowed, and returns ErrInvalidTransition otherwise.
+	ChangeStatus(ctx context.Context, id int64, status models.Status) error
+	// StatusHistory returns the status transitions of a project, newest first.
+	StatusHistory(ctx context.Context, id int64) ([]models.StatusChange, error)
+	// GitStatus returns the cached git status of a project, or nil if it
+	// is not a repository or has not been inspected yet.
+	GitStatus(ctx context.Context, id int64) (*models.GitStatus, error)
+	// GitStatuses returns the cached git status of every project that has
+	// one, by project ID.
+	GitStatuses(ctx context.Context) (map[int64]models.GitStatus, error)
+	// RefreshGitStatus inspects the repository of a project, caches the
+	// result and publishes GitStatusChanged if it differs from the cached
+	// status. Projects that are not repositories lose their cached status
+	// and return nil.
+	RefreshGitStatus(ctx context.Context, id int64) (*models.GitStatus, error)
+	// RefreshGitStatuses refreshes the git status of every project that is
+	// neither archived nor in the trash, and returns how many of them are
+	// repositories.
+	RefreshGitStatuses(ctx context.Context) (int, error)
+	// CodeStats returns the stored code statistics of a project, or nil if
+	// it has not been analysed yet.
+	CodeStats(ctx context.Context, id int64) (*models.CodeStats, error)
+	// AnalyzeProject counts the lines of the source files of a project per
+	// language, respecting .gitignore, stores the result and publishes
+	// CodeStatsChanged. Languages that became dominant since the previous
+	// analysis are added to the tags of the project.
+	AnalyzeProject(ctx context.Context, id int64) (*models.CodeStats, error)
+	// Dependencies returns the stored dependencies of a project, or nil if
+	// its manifests have not been scanned yet.
+	Dependencies(ctx context.Context, id int64) (*models.ProjectDependencies, error)
+	// ScanDependencies parses the manifests of a project, stores the
+	// dependencies declared in them and publishes DependenciesChanged. If
+	// some manifests cannot be parsed, the dependencies of the others are
+	// still stored and returned, together with an error naming the broken
+	// manifests.
+	ScanDependencies(ctx context.Context, id int64) (*models.ProjectDependencies, error)
+	// ProjectHistory returns the recorded revisions of a project, newest first.
+	ProjectHistory(ctx context.Context, id int64) ([]models.ProjectRevision, error)
+	// RevertProject restores the fields of a project to their values after
+	// the given revision, or to the values before any recorded change when
+	// revision is 0. The revert itself is recorded as a new revision.
+	RevertProject(ctx context.Context, id int64, revision int) error
+	// DeleteProject moves a project to the trash, from where it can be restored.
+	DeleteProject(ctx context.Context, id int64) error
+	// RestoreProject brings a project back from the trash.
+	RestoreProject(ctx context.Context, id int64) error
+	// PurgeProject removes a project permanently.
+	PurgeProject(ctx context.Context, id int64) error
+	// ListTrash returns the projects currently in the trash.
+	ListTrash(ctx context.Context) ([]models.Project, error)
+	// PurgeExpiredTrash permanently removes projects that have been in the
+	// trash for longer than the retention period and returns their number.
+	PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int, error)
+	// PinProject pins a project at the given 1-based position of the pinned
+	// list, or moves it there if it is pinned already. A position of 0 or
+	// past the end appends it.
+	PinProject(ctx context.Context, id int64, position int) error
+	UnpinProject(ctx context.Context, id int64) error
+	// ListPinned returns the pinned projects in pin order.
+	ListPinned(ctx context.Context) ([]models.Project, error)
+	GetProject(ctx context.Context, id int64) (*models.Project, error)
+	// ListProjects returns the projects selected by opts, sorted and paged
+	// by the database.
+	ListProjects(ctx context.Context, opts models.ListOptions) ([]models.Project, error)
+	// CountProjects returns the number of projects matching opts, ignoring
+	// its limit and offset.
+	CountProjects(ctx context.Context, opts models.ListOptions) (int, error)
+	SearchProjects(ctx context.Context, query string) ([]models.SearchResult, error)
+
+	// FuzzyFindProjects matches a pattern fzf-style against names, tags and
+	// paths, ranking frequently and recently opened projects higher.
+	FuzzyFindProjects(ctx context.Context, pattern string, limit int) ([]models.FuzzyMatch, error)
+
+	// RecordLaunch stores that a project was opened now with the given editor.
+	RecordLaunch(ctx context.Context, projectID int64, editor string) error
+	// LaunchCounts returns the number of launches per day or week since the given time.
+	LaunchCounts(ctx context.Context, period models.StatsPeriod, since time.Time) ([]models.LaunchCount, error)
+	// ProjectUsage returns launch counts per project since the given time, most used first.
+	ProjectUsage(ctx context.Context, since time.Time, limit int) ([]models.ProjectUsage, error)
+	// MostUsedThisMonth returns the projects launched most since the start of the month.
+	MostUsedThisMonth(ctx context.Context, limit int) ([]models.ProjectUsage, error)
+
+	// ListTags returns every tag together with the number of projects using it.
+	ListTags(ctx context.Context) ([]models.Tag, error)
+	// RenameTag renames a tag on all projects; renaming onto an existing tag merges them.
+	RenameTag(ctx context.Context, oldName, newName string) error
+	// MergeTags replaces each source tag with the target tag on all projects.
+	MergeTags(ctx context.Context, sources []string, target string) error
+	// DeleteTag removes a tag from all projects.
+	DeleteTag(ctx context.Context, name string) error
+
+	// ListGroups returns every group ordered by path, parents before their subgroups.
+	ListGroups(ctx context.Context) ([]models.Group, error)
+	// CreateGroup creates a group below parentID, or at the top level if it is 0.
+	CreateGroup(ctx context.Context, name string, parentID int64) (*models.Group, error)
+	RenameGroup(ctx context.Context, id int64, name string) error
+	// MoveGroup gives a group a new parent, or moves it to the top level if
+	// parentID is 0. Moving a group below itself returns ErrGroupCycle.
+	MoveGroup(ctx context.Context, id, parentID int64) error
+	// DeleteGroup removes a group and its subgroups; the projects in them are kept.
+	DeleteGroup(ctx context.Context, id int64) error
+	// ProjectGroups returns the groups a project belongs to.
+	ProjectGroups(ctx context.Context, projectID int64) ([]models.Group, error)
+	AddProjectToGroup(ctx context.Context, projectID, groupID int64) error
+	RemoveProjectFromGroup(ctx context.Context, projectID, groupID int64) error
+
+	// Subscribe registers a handler for the events published after changes
+	// to projects and returns a function that removes it again.
+	Subscribe(handler func(Event)) (unsubscribe func())
+}
+
+// Errors returned by the service that callers may want to check with errors.Is.
+var (
+	ErrNotFound       = storage.ErrNotFound
+	ErrDuplicatePath  = storage.ErrDuplicatePath
+	ErrDuplicateGroup = storage.ErrDuplicateGroup
+	ErrGroupCycle     = storage.ErrGroupCycle
+
+	// ErrTrashedDuplicatePath is returned by CreateProject when the path
+	// belongs to a project in the trash, which can be restored instead.
+	ErrTrashedDuplicatePath = errors.New("a project with this path is in the trash")
+)
+
+// TrashedDuplicateError carries the trashed project that already uses the
+// path of a new project. errors.Is sees it as ErrTrashedDuplicatePath.
+type TrashedDuplicateError struct {
+	Project *models.Project
 }
 
+func (e *TrashedDuplicateError) Error() string { return ErrTrashedDuplicatePath.Error() }
+func (e *TrashedDuplicateError) Unwrap() error { return ErrTrashedDuplicatePath }
+
 type DefaultProjectService struct {
-	repo storage.ProjectRepository
+	repo         storage.ProjectRepository
+	customFields []models.CustomFieldDefinition
+	events       *EventBus
 }
 
-func NewProjectService(repo storage.ProjectRepository) ProjectService {
-	return &DefaultProjectService{repo: repo}
+// NewProjectService returns a service storing projects in repo, with the
+// given custom fields available on every project.
+func NewProjectService(repo storage.ProjectRepository, customFields []models.CustomFieldDefinition) ProjectService {
+	return &DefaultProjectService{repo: repo, customFields: customFields, events: NewEventBus()}
 }
 
-func (s *DefaultProjectService) CreateProject(project *models.Project) error {
-	return s.repo.Create(project)
+// withRepo returns a copy of the service that uses repo, typically one
+// bound to a transaction. The copy publishes no events; that is left to the
+// caller once the transaction has been committed.
+func (s *DefaultProjectService) withRepo(repo storage.ProjectRepository) *DefaultProjectService {
+	copied := *s
+	copied.repo = repo
+	copied.events = nil
+	return &copied
 }
 
-func (s *DefaultProjectService) UpdateProject(project *models.Project) error {
-	return s.repo.Update(project)
+// CreateProject normalises the path of the project and validates its
+// fields, reporting every invalid one in a *ValidationError, before storing it.
+func (s *DefaultProjectService) CreateProject(ctx context.Context, project *models.Project) error {
+	err := s.prepareProject(project)
+	if err != nil {
+		return err
+	}
+
+	if project.GitRootCommit == "" {
+		project.GitRootCommit = gitRootCommit(project.Path)
+	}
+
+	err = s.repo.Create(ctx, project)
+	if errors.Is(err, ErrDuplicatePath) {
+		existing, getErr := s.repo.GetByPath(ctx, project.Path)
+		if getErr == nil && !existing.DeletedAt.IsZero() {
+			return &TrashedDuplicateError{Project: existing}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	s.publishStored(ctx, project.ID, created)
+	return nil
 }
 
-func (s *DefaultProjectService) DeleteProject(id int64) error {
-	return s.repo.Delete(id)
+func (s *DefaultProjectService) ImportProjects(ctx context.Context, projects []*models.Project) (models.ImportResult, error) {
+	var result models.ImportResult
+
+	err := s.repo.WithTx(ctx, func(repo storage.ProjectRepository) error {
+		for _, project := range projects {
+			err := s.prepareProject(project)
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", project.Path, err)
+			}
+
+			if project.GitRootCommit == "" {
+				project.GitRootCommit = gitRootCommit(project.Path)
+			}
+
+			created, err := repo.CreateOrUpdateByPath(ctx, project)
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", project.Path, err)
+			}
+
+			// Importing the folder again asks for the project back.
+			if !created && !project.DeletedAt.IsZero() {
+				err = repo.Restore(ctx, project.ID)
+				if err != nil {
+					return fmt.Errorf("failed to restore %s: %w", project.Path, err)
+				}
+				restored, err := repo.GetByID(ctx, project.ID)
+				if err != nil {
+					return err
+				}
+				*project = *restored
+				result.Restored = append(result.Restored, project)
+				continue
+			}
+
+			if created {
+				result.Created = append(result.Created, project)
+			} else {
+				result.Existing = append(result.Existing, project)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+
+	for _, project := range result.Created {
+		s.publishStored(ctx, project.ID, created)
+	}
+	for _, project := range result.Restored {
+		s.publishStored(ctx, project.ID, created)
+	}
+	for _, project := range result.Existing {
+		s.publishStored(ctx, project.ID, updated)
+	}
+
+	return result, nil
 }
 
-func (s *DefaultProjectService) GetProject(id int64) (*models.Project, error) {
-	return s.repo.GetByID(id)
+// UpdateProject saves the project and records the fields it changed as a new
+// revision in the project's history.
+func (s *DefaultProjectService) UpdateProject(ctx context.Context, project *models.Project) error {
+	invalid := &ValidationError{}
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		invalid.add(models.FieldName, ErrEmptyName)
+	}
+	s.checkCustomFields(project, invalid)
+
+	err := invalid.orNil()
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.WithTx(ctx, func(repo storage.ProjectRepository) error {
+		before, err := repo.GetByID(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+
+		err = repo.Update(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		// Compare against the stored project so that normalised values such
+		// as tags do not show up as changes.
+		after, err := repo.GetByID(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+
+		changes := diffProjects(before, after)
+		if len(changes) == 0 {
+			return nil
+		}
+
+		_, err = repo.RecordChanges(ctx, project.ID, changes, time.Now())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishStored(ctx, project.ID, updated)
+	return nil
 }
 
-func (s *DefaultProjectService) ListProjects() ([]models.Project, error) {
-	return s.repo.ListAll()
+func (s *DefaultProjectService) DeleteProject(ctx context.Context, id int64) error {
+	return s.changePins(ctx, []Event{ProjectDeleted{ID: id}}, func(repo storage.ProjectRepository) error {
+		return repo.Delete(ctx, id)
+	})
 }
 
-func (s *DefaultProjectService) SearchProjects(query string) ([]models.Project, error) {
-	projects, err := s.repo.ListAll()
+func (s *DefaultProjectService) RestoreProject(ctx context.Context, id int64) error {
+	err := s.repo.Restore(ctx, id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Simple case-insensitive search
-	var results []models.Project
-	query = strings.ToLower(query)
-	for _, project := range projects {
-		if strings.Contains(strings.ToLower(project.Name), query) ||
-			strings.Contains(strings.ToLower(project.Description), query) {
-			results = append(results, project)
+	s.publishStored(ctx, id, created)
+	return nil
+}
+
+func (s *DefaultProjectService) PurgeProject(ctx context.Context, id int64) error {
+	err := s.repo.Purge(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.events.Publish(ProjectDeleted{ID: id})
+	return nil
+}
+
+func (s *DefaultProjectService) ListTrash(ctx context.Context) ([]models.Project, error) {
+	return s.repo.ListTrash(ctx)
+}
+
+func (s *DefaultProjectService) PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int, error) {
+	purged, err := s.repo.PurgeTrash(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range purged {
+		s.events.Publish(ProjectDeleted{ID: id})
+	}
+	return len(purged), nil
+}
+
+func (s *DefaultProjectService) PinProject(ctx context.Context, id int64, position int) error {
+	return s.changePins(ctx, nil, func(repo storage.ProjectRepository) error {
+		return repo.Pin(ctx, id, position)
+	})
+}
+
+func (s *DefaultProjectService) UnpinProject(ctx context.Context, id int64) error {
+	return s.changePins(ctx, nil, func(repo storage.ProjectRepository) error {
+		return repo.Unpin(ctx, id)
+	})
+}
+
+// changePins runs a change that may move projects in the pinned list and
+// publishes events, followed by an update for every project whose pin
+// position changed.
+func (s *DefaultProjectService) changePins(ctx context.Context, events []Event, change func(repo storage.ProjectRepository) error) error {
+	err := s.repo.WithTx(ctx, func(repo storage.ProjectRepository) error {
+		before, err := repo.ListPinned(ctx)
+		if err != nil {
+			return err
 		}
+
+		err = change(repo)
+		if err != nil {
+			return err
+		}
+
+		moved, err := pinChanges(ctx, repo, before)
+		if err != nil {
+			return err
+		}
+		events = append(events, moved...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.events.Publish(events...)
+	return nil
+}
+
+func (s *DefaultProjectService) ListPinned(ctx context.Context) ([]models.Project, error) {
+	return s.repo.ListPinned(ctx)
+}
+
+func (s *DefaultProjectService) GetProject(ctx context.Context, id int64) (*models.Project, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *DefaultProjectService) ListProjects(ctx context.Context, opts models.ListOptions) ([]models.Project, error) {
+	return s.repo.List(ctx, opts)
+}
+
+func (s *DefaultProjectService) CountProjects(ctx context.Context, opts models.ListOptions) (int, error) {
+	return s.repo.Count(ctx, opts)
+}
+
+// SearchProjects parses a query in the syntax of the query package and runs
+// it against the full-text index, returning the best matches first. Syntax
+// problems are reported as a *query.SyntaxError.
+func (s *DefaultProjectService) SearchProjects(ctx context.Context, queryText string) ([]models.SearchResult, error) {
+	names := make([]string, len(s.customFields))
+	for i, field := range s.customFields {
+		names[i] = field.Name
+	}
+
+	parsed, err := query.ParseWithFields(queryText, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Search(ctx, parsed)
+}
+
+func (s *DefaultProjectService) ListTags(ctx context.Context) ([]models.Tag, error) {
+	return s.repo.ListTags(ctx)
+}
+
+func (s *DefaultProjectService) RenameTag(ctx context.Context, oldName, newName string) error {
+	ids, err := s.repo.RenameTag(ctx, oldName, newName)
+	return s.publishTagged(ctx, ids, err)
+}
+
+func (s *DefaultProjectService) MergeTags(ctx context.Context, sources []string, target string) error {
+	ids, err := s.repo.MergeTags(ctx, sources, target)
+	return s.publishTagged(ctx, ids, err)
+}
+
+func (s *DefaultProjectService) DeleteTag(ctx context.Context, name string) error {
+	ids, err := s.repo.DeleteTag(ctx, name)
+	return s.publishTagged(ctx, ids, err)
+}
+
+// publishTagged publishes an update of every project whose tags were
+// changed by a tag management call, unless the call failed.
+func (s *DefaultProjectService) publishTagged(ctx context.Context, ids []int64, err error) error {
+	if err != nil {
+		return err
 	}
 
-	return results, nil
+	// A merge lists projects that had several of the merged tags repeatedly.
+	slices.Sort(ids)
+	for _, id := range slices.Compact(ids) {
+		s.publishStored(ctx, id, updated)
+	}
+	return nil
 }
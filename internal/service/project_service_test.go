@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestCreateProjectReportsTrashedDuplicate(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	dir := newTestDir(t)
+
+	project := &models.Project{Name: "proj", Path: dir}
+	if err := s.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	err := s.CreateProject(ctx, &models.Project{Name: "again", Path: dir})
+	if !errors.Is(err, ErrDuplicatePath) {
+		t.Fatalf("CreateProject of a registered path = %v, want ErrDuplicatePath", err)
+	}
+
+	if err := s.DeleteProject(ctx, project.ID); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+	err = s.CreateProject(ctx, &models.Project{Name: "again", Path: dir})
+	var trashed *TrashedDuplicateError
+	if !errors.As(err, &trashed) {
+		t.Fatalf("CreateProject of a trashed path = %v, want *TrashedDuplicateError", err)
+	}
+	if trashed.Project.ID != project.ID {
+		t.Errorf("trashed project ID = %d, want %d", trashed.Project.ID, project.ID)
+	}
+	if !errors.Is(err, ErrTrashedDuplicatePath) || errors.Is(err, ErrDuplicatePath) {
+		t.Errorf("errors.Is(%v) does not single out ErrTrashedDuplicatePath", err)
+	}
+}
+
+func TestTagChangesAndPurgePublishEvents(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	tagged := &models.Project{Name: "tagged", Path: newTestDir(t), Tags: []string{"old", "other"}}
+	untagged := &models.Project{Name: "untagged", Path: newTestDir(t)}
+	for _, project := range []*models.Project{tagged, untagged} {
+		if err := s.CreateProject(ctx, project); err != nil {
+			t.Fatalf("CreateProject: %v", err)
+		}
+	}
+
+	var events []Event
+	unsubscribe := s.Subscribe(func(event Event) { events = append(events, event) })
+	defer unsubscribe()
+
+	if err := s.RenameTag(ctx, "old", "new"); err != nil {
+		t.Fatalf("RenameTag: %v", err)
+	}
+	if err := s.MergeTags(ctx, []string{"new", "other"}, "merged"); err != nil {
+		t.Fatalf("MergeTags: %v", err)
+	}
+	if err := s.DeleteTag(ctx, "merged"); err != nil {
+		t.Fatalf("DeleteTag: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("tag changes published %d events, want 3: %v", len(events), events)
+	}
+	for _, event := range events {
+		if updated, ok := event.(ProjectUpdated); !ok || updated.Project.ID != tagged.ID {
+			t.Errorf("tag change published %#v, want an update of project %d", event, tagged.ID)
+		}
+	}
+
+	events = nil
+	if err := s.PurgeProject(ctx, untagged.ID); err != nil {
+		t.Fatalf("PurgeProject: %v", err)
+	}
+	if len(events) != 1 || events[0] != (ProjectDeleted{ID: untagged.ID}) {
+		t.Errorf("PurgeProject published %v, want ProjectDeleted of project %d", events, untagged.ID)
+	}
+}
+
+func TestImportProjectsRestoresTrashed(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	dir := newTestDir(t)
+
+	project := &models.Project{Name: "proj", Path: dir}
+	if err := s.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if err := s.DeleteProject(ctx, project.ID); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+
+	result, err := s.ImportProjects(ctx, []*models.Project{{Name: "again", Path: dir}})
+	if err != nil {
+		t.Fatalf("ImportProjects: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Existing) != 0 || len(result.Restored) != 1 {
+		t.Fatalf("import created %d, refreshed %d and restored %d projects, want 1 restored",
+			len(result.Created), len(result.Existing), len(result.Restored))
+	}
+	restored, err := s.GetProject(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if !restored.DeletedAt.IsZero() {
+		t.Error("re-imported project is still in the trash")
+	}
+}
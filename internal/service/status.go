@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+)
+
+// ErrInvalidTransition is returned when a project cannot change from its
+// current status to the requested one.
+var ErrInvalidTransition = errors.New("status change not allowed")
+
+func (s *DefaultProjectService) ChangeStatus(ctx context.Context, id int64, status models.Status) error {
+	if _, err := models.ParseStatus(string(status)); err != nil {
+		return err
+	}
+
+	changed := false
+	err := s.repo.WithTx(ctx, func(repo storage.ProjectRepository) error {
+		project, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if project.Status == status {
+			return nil
+		}
+		if !project.Status.CanTransition(status) {
+			return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, project.Status, status)
+		}
+
+		changed = true
+		return repo.SetStatus(ctx, id, status, time.Now())
+	})
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		s.publishStored(ctx, id, updated)
+	}
+	return nil
+}
+
+func (s *DefaultProjectService) StatusHistory(ctx context.Context, id int64) ([]models.StatusChange, error) {
+	return s.repo.ListStatusHistory(ctx, id)
+}
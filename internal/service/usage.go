@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func (s *DefaultProjectService) RecordLaunch(ctx context.Context, projectID int64, editor string) error {
+	err := s.repo.RecordLaunch(ctx, projectID, editor, time.Now())
+	if err != nil {
+		return err
+	}
+
+	s.publishStored(ctx, projectID, func(project models.Project) Event {
+		return ProjectOpened{Project: project, Editor: editor}
+	})
+	return nil
+}
+
+func (s *DefaultProjectService) LaunchCounts(ctx context.Context, period models.StatsPeriod, since time.Time) ([]models.LaunchCount, error) {
+	return s.repo.LaunchCounts(ctx, period, since)
+}
+
+func (s *DefaultProjectService) ProjectUsage(ctx context.Context, since time.Time, limit int) ([]models.ProjectUsage, error) {
+	return s.repo.ProjectUsage(ctx, since, limit)
+}
+
+func (s *DefaultProjectService) MostUsedThisMonth(ctx context.Context, limit int) ([]models.ProjectUsage, error) {
+	now := time.Now()
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return s.repo.ProjectUsage(ctx, startOfMonth, limit)
+}
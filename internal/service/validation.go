@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/pkg/utils"
+)
+
+// ErrEmptyName is returned when a project has no name.
+var ErrEmptyName = errors.New("project name cannot be empty")
+
+// FieldError is an invalid value of a single project field.
+type FieldError struct {
+	// Field names the field like the change history does, e.g.
+	// models.FieldName, models.FieldPath or a custom field prefixed with
+	// models.CustomFieldPrefix.
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string { return e.Err.Error() }
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// ValidationError lists every invalid field of a project, so that a form
+// can point out all of them at once. errors.Is sees through it to the
+// errors of the fields, such as ErrInvalidPath.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+	return errs
+}
+
+// Field returns the error of the named field, or nil if it is valid.
+func (e *ValidationError) Field(name string) error {
+	for _, field := range e.Fields {
+		if field.Field == name {
+			return field.Err
+		}
+	}
+	return nil
+}
+
+// add records an invalid field.
+func (e *ValidationError) add(field string, err error) {
+	e.Fields = append(e.Fields, &FieldError{Field: field, Err: err})
+}
+
+// orNil returns the error if any field is invalid, and nil otherwise.
+func (e *ValidationError) orNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// NormalizePath expands a leading ~, makes the path absolute, resolves
+// symbolic links and strips trailing separators, so that a directory is
+// registered under the same path however it was typed. Paths that do not
+// exist are normalised as far as possible.
+func NormalizePath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot expand ~: %v", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return path, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve %s: %v", path, err)
+	}
+	return resolved, nil
+}
+
+// prepareProject trims the name and normalises the path of a project that
+// is about to be registered, and validates all of its fields.
+func (s *DefaultProjectService) prepareProject(project *models.Project) error {
+	invalid := &ValidationError{}
+
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		invalid.add(models.FieldName, ErrEmptyName)
+	}
+
+	path, err := NormalizePath(project.Path)
+	if err == nil {
+		err = utils.ValidateProjectPath(path)
+	}
+	if err != nil {
+		invalid.add(models.FieldPath, fmt.Errorf("%w: %v", ErrInvalidPath, err))
+	} else {
+		project.Path = path
+	}
+
+	if project.Status != "" {
+		if _, err := models.ParseStatus(string(project.Status)); err != nil {
+			invalid.add(models.FieldStatus, err)
+		}
+	}
+
+	s.checkCustomFields(project, invalid)
+	return invalid.orNil()
+}
+
+// prepareImported trims the name and normalises the path of a project read
+// from a catalog, and validates its fields like prepareProject. The path
+// does not have to exist, since catalogs are moved between machines; paths
+// missing here are reported by the missing path detection instead.
+func (s *DefaultProjectService) prepareImported(project *models.Project) error {
+	invalid := &ValidationError{}
+
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		invalid.add(models.FieldName, ErrEmptyName)
+	}
+
+	path, err := NormalizePath(project.Path)
+	if err != nil {
+		invalid.add(models.FieldPath, fmt.Errorf("%w: %v", ErrInvalidPath, err))
+	} else {
+		project.Path = path
+	}
+
+	if project.Status == "" {
+		project.Status = models.DefaultStatus
+	}
+	if _, err := models.ParseStatus(string(project.Status)); err != nil {
+		invalid.add(models.FieldStatus, err)
+	}
+
+	s.checkCustomFields(project, invalid)
+	return invalid.orNil()
+}
+
+// CheckProject prepares a new project like CreateProject does, without
+// storing it. Besides validation errors it returns the registered projects
+// that the new one would sit inside or contain; nesting is allowed, but
+// usually registers the same code twice.
+func (s *DefaultProjectService) CheckProject(ctx context.Context, project *models.Project) ([]models.Project, error) {
+	err := s.prepareProject(project)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.repo.List(ctx, models.ListOptions{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var nested []models.Project
+	for _, registered := range projects {
+		if registered.ID != project.ID && (isWithin(project.Path, registered.Path) || isWithin(registered.Path, project.Path)) {
+			nested = append(nested, registered)
+		}
+	}
+	return nested, nil
+}
+
+// isWithin reports whether path lies below dir.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
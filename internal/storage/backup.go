@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// backupTimeLayout is embedded in backup file names, which sort by age.
+const backupTimeLayout = "20060102-150405.000"
+
+// IntegrityCheck runs PRAGMA integrity_check and returns an error wrapping
+// ErrCorrupt that lists the problems found.
+func (s *SQLiteStorage) IntegrityCheck(ctx context.Context) error {
+	return integrityCheck(ctx, s.db)
+}
+
+func integrityCheck(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		// A database too damaged to read counts as corrupt as well.
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return fmt.Errorf("failed to read integrity check: %v", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrCorrupt, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// Backup checks the integrity of the database and writes a consistent copy
+// of it into dir. A corrupt database is not backed up, so that it cannot
+// rotate good backups away.
+func (s *SQLiteStorage) Backup(ctx context.Context, dir string) (models.Backup, error) {
+	err := s.IntegrityCheck(ctx)
+	if err != nil {
+		return models.Backup{}, err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return models.Backup{}, fmt.Errorf("failed to create backup directory: %v", err)
+	}
+
+	now := time.Now()
+	backupPath := filepath.Join(dir, s.backupPrefix()+now.Format(backupTimeLayout)+".db")
+	err = backupDatabase(ctx, s.db, backupPath)
+	if err != nil {
+		return models.Backup{}, fmt.Errorf("failed to back up database: %v", err)
+	}
+
+	info, err := os.Stat(backupPath)
+	if err != nil {
+		return models.Backup{}, fmt.Errorf("failed to read backup: %v", err)
+	}
+
+	return models.Backup{Path: backupPath, CreatedAt: now, Size: info.Size()}, nil
+}
+
+// backupPrefix starts the names of this database's backups, like "projects-".
+func (s *SQLiteStorage) backupPrefix() string {
+	base := filepath.Base(s.path)
+	return strings.TrimSuffix(base, filepath.Ext(base)) + "-"
+}
+
+// ListBackups returns the backups of this database in dir, newest first.
+func (s *SQLiteStorage) ListBackups(dir string) ([]models.Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %v", err)
+	}
+
+	var backups []models.Backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, s.backupPrefix()) || !strings.HasSuffix(name, ".db") {
+			continue
+		}
+
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, s.backupPrefix()), ".db")
+		createdAt, err := time.ParseInLocation(backupTimeLayout, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup %s: %v", name, err)
+		}
+
+		backups = append(backups, models.Backup{
+			Path:      filepath.Join(dir, name),
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// RotateBackups deletes all but the newest keep backups in dir.
+func (s *SQLiteStorage) RotateBackups(dir string, keep int) error {
+	backups, err := s.ListBackups(dir)
+	if err != nil {
+		return err
+	}
+
+	for i := max(keep, 0); i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil {
+			return fmt.Errorf("failed to remove old backup: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Restore replaces the contents of the database with a backup using the
+// SQLite online backup API, so open connections stay usable. The backup is
+// checked first, and the current database is kept next to it in case
+// anything in it is still needed: with a ".corrupt" suffix if it fails its
+// own integrity check, and with a ".before-restore" suffix otherwise.
+func (s *SQLiteStorage) Restore(ctx context.Context, backupPath string) error {
+	source, err := sql.Open("sqlite3", "file:"+backupPath+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %v", err)
+	}
+	defer source.Close()
+
+	err = integrityCheck(ctx, source)
+	if err != nil {
+		return fmt.Errorf("backup %s: %w", filepath.Base(backupPath), err)
+	}
+
+	suffix := ".before-restore"
+	if integrityCheck(ctx, s.db) != nil {
+		suffix = ".corrupt"
+	}
+	err = s.keepCopy(ctx, suffix)
+	if err != nil {
+		return fmt.Errorf("failed to keep a copy of the current database: %v", err)
+	}
+
+	err = copyDatabase(ctx, s.db, source)
+	if err != nil {
+		return fmt.Errorf("failed to restore backup: %v", err)
+	}
+
+	// The backup may predate migrations added since it was taken.
+	err = migrate(ctx, s.db, s.path)
+	if err != nil {
+		return fmt.Errorf("failed to migrate restored database: %w", err)
+	}
+
+	return nil
+}
+
+// keepCopy copies the database file next to it, named with the time and
+// suffix. Committed changes still in the write-ahead log are checkpointed
+// into the file first; if damage prevents that, the log is copied along
+// under the name SQLite looks for when the copy is opened.
+func (s *SQLiteStorage) keepCopy(ctx context.Context, suffix string) error {
+	copyPath := s.path + "." + time.Now().Format("20060102-150405") + suffix
+
+	_, checkpointErr := s.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)")
+
+	err := copyFile(s.path, copyPath)
+	if err != nil {
+		return err
+	}
+
+	if checkpointErr == nil {
+		return nil
+	}
+	err = copyFile(s.path+"-wal", copyPath+"-wal")
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// copyDatabase copies every page of source into destination.
+func copyDatabase(ctx context.Context, destination, source *sql.DB) error {
+	destConn, err := destination.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer destConn.Close()
+
+	srcConn, err := source.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	return destConn.Raw(func(destDriverConn any) error {
+		return srcConn.Raw(func(srcDriverConn any) error {
+			backup, err := destDriverConn.(*sqlite3.SQLiteConn).Backup("main", srcDriverConn.(*sqlite3.SQLiteConn), "main")
+			if err != nil {
+				return err
+			}
+
+			_, err = backup.Step(-1)
+			if finishErr := backup.Finish(); err == nil {
+				err = finishErr
+			}
+			return err
+		})
+	})
+}
+
+func copyFile(source, destination string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
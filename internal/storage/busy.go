@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// busyTimeout is how long SQLite itself waits for a lock held by another
+// connection or process before reporting SQLITE_BUSY.
+const busyTimeout = 5 * time.Second
+
+// busyRetries is how often a lock is retried after the busy timeout expired,
+// with the wait doubling from busyRetryDelay each time.
+const (
+	busyRetries    = 4
+	busyRetryDelay = 100 * time.Millisecond
+)
+
+// isBusy reports whether err means the database is locked by someone else.
+func isBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) &&
+		(sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked)
+}
+
+// retryBusy calls fn again while it fails because the database is locked.
+// fn must be safe to repeat, such as beginning a transaction.
+func retryBusy(ctx context.Context, fn func() error) error {
+	delay := busyRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !isBusy(err) || attempt == busyRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// beginTx starts a write transaction. The connection string makes every
+// transaction BEGIN IMMEDIATE, so the write lock is taken up front and at
+// most one writer, in any process, is active at a time. Waiting for the lock
+// is the only point where a transaction can fail with SQLITE_BUSY, which
+// makes it the one place that needs to retry.
+func beginTx(ctx context.Context, db *sql.DB) (*sql.Tx, error) {
+	var tx *sql.Tx
+	err := retryBusy(ctx, func() error {
+		var err error
+		tx, err = db.BeginTx(ctx, nil)
+		return err
+	})
+	return tx, err
+}
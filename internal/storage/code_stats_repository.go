@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// SetCodeStats replaces the stored code statistics of a project.
+func (r *SQLiteProjectRepository) SetCodeStats(ctx context.Context, stats *models.CodeStats) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		// Deleting the old statistics also deletes their languages.
+		_, err := tx.ExecContext(ctx, "DELETE FROM project_code_stats WHERE project_id = ?", stats.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to replace code statistics: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO project_code_stats (project_id, analyzed_at) VALUES (?, ?)",
+			stats.ProjectID, stats.AnalyzedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store code statistics of project %d: %w", stats.ProjectID, mapError(err))
+		}
+
+		for _, lang := range stats.Languages {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO project_languages (project_id, language, files, code, comments, blanks)
+				VALUES (?, ?, ?, ?, ?, ?)
+			`, stats.ProjectID, lang.Language, lang.Files, lang.Code, lang.Comments, lang.Blanks)
+			if err != nil {
+				return fmt.Errorf("failed to store statistics for %s: %v", lang.Language, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetCodeStats returns the stored code statistics of a project, or
+// ErrNotFound if it has not been analysed.
+func (r *SQLiteProjectRepository) GetCodeStats(ctx context.Context, projectID int64) (*models.CodeStats, error) {
+	stats := &models.CodeStats{ProjectID: projectID}
+	err := r.conn().QueryRowContext(ctx,
+		"SELECT analyzed_at FROM project_code_stats WHERE project_id = ?", projectID,
+	).Scan(&stats.AnalyzedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code statistics of project %d: %w", projectID, mapError(err))
+	}
+
+	rows, err := r.conn().QueryContext(ctx, `
+		SELECT language, files, code, comments, blanks
+		FROM project_languages
+		WHERE project_id = ?
+		ORDER BY code DESC, language
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query languages: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var lang models.LanguageStats
+		if err := rows.Scan(&lang.Language, &lang.Files, &lang.Code, &lang.Comments, &lang.Blanks); err != nil {
+			return nil, fmt.Errorf("failed to scan language: %v", err)
+		}
+		stats.Languages = append(stats.Languages, lang)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading languages: %v", err)
+	}
+
+	return stats, nil
+}
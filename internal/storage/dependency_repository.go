@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// SetDependencies replaces the stored dependencies of a project.
+func (r *SQLiteProjectRepository) SetDependencies(ctx context.Context, deps *models.ProjectDependencies) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		// Deleting the old scan also deletes its dependencies.
+		_, err := tx.ExecContext(ctx, "DELETE FROM project_dependency_scans WHERE project_id = ?", deps.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to replace dependencies: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO project_dependency_scans (project_id, scanned_at) VALUES (?, ?)",
+			deps.ProjectID, deps.ScannedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store dependencies of project %d: %w", deps.ProjectID, mapError(err))
+		}
+
+		for _, dep := range deps.Dependencies {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO project_dependencies (project_id, ecosystem, name, version, scope, manifest, indirect)
+				VALUES (?, ?, ?, ?, ?, ?, ?)
+			`, deps.ProjectID, dep.Ecosystem, dep.Name, dep.Version, string(dep.Scope), dep.Manifest, dep.Indirect)
+			if err != nil {
+				return fmt.Errorf("failed to store dependency %s: %v", dep.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetDependencies returns the stored dependencies of a project in the order
+// they were found, or ErrNotFound if its manifests have not been scanned.
+func (r *SQLiteProjectRepository) GetDependencies(ctx context.Context, projectID int64) (*models.ProjectDependencies, error) {
+	deps := &models.ProjectDependencies{ProjectID: projectID}
+	err := r.conn().QueryRowContext(ctx,
+		"SELECT scanned_at FROM project_dependency_scans WHERE project_id = ?", projectID,
+	).Scan(&deps.ScannedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies of project %d: %w", projectID, mapError(err))
+	}
+
+	rows, err := r.conn().QueryContext(ctx, `
+		SELECT ecosystem, name, version, scope, manifest, indirect
+		FROM project_dependencies
+		WHERE project_id = ?
+		ORDER BY id
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependencies: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dep models.Dependency
+		var scope string
+		if err := rows.Scan(&dep.Ecosystem, &dep.Name, &dep.Version, &scope, &dep.Manifest, &dep.Indirect); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %v", err)
+		}
+		dep.Scope = models.DependencyScope(scope)
+		deps.Dependencies = append(deps.Dependencies, dep)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading dependencies: %v", err)
+	}
+
+	return deps, nil
+}
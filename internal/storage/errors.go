@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+var (
+	// ErrNotFound is returned when the requested project or tag does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrDuplicatePath is returned when a project with the same path is already registered.
+	ErrDuplicatePath = errors.New("a project with this path is already registered")
+	// ErrDuplicateGroup is returned when a group with the same name already exists under the same parent.
+	ErrDuplicateGroup = errors.New("a group with this name already exists")
+	// ErrGroupCycle is returned when a group would be moved into itself or one of its subgroups.
+	ErrGroupCycle = errors.New("a group cannot be moved into itself or its subgroups")
+	// ErrCorrupt is returned when a database fails PRAGMA integrity_check.
+	ErrCorrupt = errors.New("database failed the integrity check")
+)
+
+// mapError translates database/sql and sqlite driver errors into the
+// sentinel errors of this package. Other errors are returned unchanged.
+func mapError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		switch {
+		case strings.Contains(sqliteErr.Error(), "projects.path"):
+			return ErrDuplicatePath
+		case strings.Contains(sqliteErr.Error(), "idx_groups_parent_name"):
+			return ErrDuplicateGroup
+		}
+	}
+
+	return err
+}
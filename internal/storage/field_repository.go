@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/query"
+)
+
+// setProjectFields replaces the custom field values of a project. Empty
+// values are not stored.
+func setProjectFields(ctx context.Context, q dbtx, projectID int64, fields map[string]string) error {
+	_, err := q.ExecContext(ctx, "DELETE FROM project_fields WHERE project_id = ?", projectID)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+
+		_, err = q.ExecContext(ctx,
+			"INSERT INTO project_fields (project_id, name, value) VALUES (?, ?, ?)",
+			projectID,
+			name,
+			value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getProjectFields(ctx context.Context, q dbtx, projectID int64) (map[string]string, error) {
+	rows, err := q.QueryContext(ctx, "SELECT name, value FROM project_fields WHERE project_id = ?", projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields map[string]string
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		fields[name] = value
+	}
+
+	return fields, rows.Err()
+}
+
+// attachFields loads the custom fields of all given projects with a single query.
+func attachFields(ctx context.Context, q dbtx, projects []models.Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT project_id, name, value FROM project_fields")
+	if err != nil {
+		return fmt.Errorf("failed to query project fields: %v", err)
+	}
+	defer rows.Close()
+
+	fieldsByProject := make(map[int64]map[string]string)
+	for rows.Next() {
+		var projectID int64
+		var name, value string
+		if err := rows.Scan(&projectID, &name, &value); err != nil {
+			return fmt.Errorf("failed to scan project field: %v", err)
+		}
+		if fieldsByProject[projectID] == nil {
+			fieldsByProject[projectID] = make(map[string]string)
+		}
+		fieldsByProject[projectID][name] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading project fields: %v", err)
+	}
+
+	for i := range projects {
+		projects[i].CustomFields = fieldsByProject[projects[i].ID]
+	}
+
+	return nil
+}
+
+// compileCustomField translates a term on a custom field. Values that parse
+// as numbers are compared numerically; everything else, including dates in
+// YYYY-MM-DD form, is compared as text. Without an operator, numbers have to
+// be equal and text has to be contained in the value.
+func compileCustomField(term query.Term) (string, []any, error) {
+	condition := "f.value LIKE ? ESCAPE '\\'"
+	value := any("%" + escapeLike(term.Value) + "%")
+
+	number, err := strconv.ParseFloat(term.Value, 64)
+	isNumber := err == nil
+
+	switch {
+	case isNumber && term.Op == query.OpNone:
+		condition, value = "CAST(f.value AS REAL) = ?", number
+	case isNumber:
+		condition, value = "CAST(f.value AS REAL) "+term.Op+" ?", number
+	case term.Op != query.OpNone:
+		condition, value = "f.value "+term.Op+" ?", term.Value
+	}
+
+	return `EXISTS (
+		SELECT 1 FROM project_fields f
+		WHERE f.project_id = p.id AND f.name = ? AND ` + condition + `
+	)`, []any{term.Field, value}, nil
+}
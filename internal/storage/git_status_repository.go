@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+const gitStatusColumns = `
+	project_id, branch, upstream, ahead, behind,
+	staged, modified, untracked, conflicted,
+	last_commit_at, last_commit_author, checked_at
+`
+
+// SetGitStatus replaces the cached git status of a project, remotes included.
+func (r *SQLiteProjectRepository) SetGitStatus(ctx context.Context, status *models.GitStatus) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		// Deleting the old status also deletes its remotes.
+		_, err := tx.ExecContext(ctx, "DELETE FROM project_git_status WHERE project_id = ?", status.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to replace git status: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO project_git_status (`+gitStatusColumns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			status.ProjectID, status.Branch, status.Upstream, status.Ahead, status.Behind,
+			status.Staged, status.Modified, status.Untracked, status.Conflicted,
+			nullTime(status.LastCommitAt), status.LastCommitAuthor, status.CheckedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store git status of project %d: %w", status.ProjectID, mapError(err))
+		}
+
+		for name, url := range status.Remotes {
+			_, err = tx.ExecContext(ctx,
+				"INSERT INTO project_git_remotes (project_id, name, url) VALUES (?, ?, ?)",
+				status.ProjectID, name, url,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to store git remote %s: %v", name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *SQLiteProjectRepository) DeleteGitStatus(ctx context.Context, projectID int64) error {
+	_, err := r.conn().ExecContext(ctx, "DELETE FROM project_git_status WHERE project_id = ?", projectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete git status: %v", err)
+	}
+	return nil
+}
+
+// GetGitStatus returns the cached git status of a project, or ErrNotFound
+// if the project has never been inspected or is not a repository.
+func (r *SQLiteProjectRepository) GetGitStatus(ctx context.Context, projectID int64) (*models.GitStatus, error) {
+	row := r.conn().QueryRowContext(ctx, "SELECT "+gitStatusColumns+" FROM project_git_status WHERE project_id = ?", projectID)
+	status, err := scanGitStatus(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git status of project %d: %w", projectID, mapError(err))
+	}
+
+	remotes, err := r.listGitRemotes(ctx, "WHERE project_id = ?", projectID)
+	if err != nil {
+		return nil, err
+	}
+	status.Remotes = remotes[projectID]
+
+	return &status, nil
+}
+
+// ListGitStatuses returns the cached git status of every project that has one.
+func (r *SQLiteProjectRepository) ListGitStatuses(ctx context.Context) ([]models.GitStatus, error) {
+	rows, err := r.conn().QueryContext(ctx, "SELECT "+gitStatusColumns+" FROM project_git_status ORDER BY project_id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query git status: %v", err)
+	}
+	defer rows.Close()
+
+	var statuses []models.GitStatus
+	for rows.Next() {
+		status, err := scanGitStatus(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan git status: %v", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading git status: %v", err)
+	}
+
+	remotes, err := r.listGitRemotes(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range statuses {
+		statuses[i].Remotes = remotes[statuses[i].ProjectID]
+	}
+
+	return statuses, nil
+}
+
+// listGitRemotes returns the remotes matched by the where clause, by project ID.
+func (r *SQLiteProjectRepository) listGitRemotes(ctx context.Context, where string, args ...any) (map[int64]map[string]string, error) {
+	rows, err := r.conn().QueryContext(ctx, "SELECT project_id, name, url FROM project_git_remotes "+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query git remotes: %v", err)
+	}
+	defer rows.Close()
+
+	remotes := make(map[int64]map[string]string)
+	for rows.Next() {
+		var projectID int64
+		var name, url string
+		if err := rows.Scan(&projectID, &name, &url); err != nil {
+			return nil, fmt.Errorf("failed to scan git remote: %v", err)
+		}
+		if remotes[projectID] == nil {
+			remotes[projectID] = make(map[string]string)
+		}
+		remotes[projectID][name] = url
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading git remotes: %v", err)
+	}
+
+	return remotes, nil
+}
+
+// scanGitStatus reads a row selected with gitStatusColumns.
+func scanGitStatus(row rowScanner) (models.GitStatus, error) {
+	var status models.GitStatus
+	var lastCommitAt sql.NullTime
+	err := row.Scan(
+		&status.ProjectID, &status.Branch, &status.Upstream, &status.Ahead, &status.Behind,
+		&status.Staged, &status.Modified, &status.Untracked, &status.Conflicted,
+		&lastCommitAt, &status.LastCommitAuthor, &status.CheckedAt,
+	)
+	status.LastCommitAt = lastCommitAt.Time
+	return status, err
+}
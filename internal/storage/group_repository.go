@@ -0,0 +1,326 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// groupTree is a common table expression naming every group together with
+// its path, for queries that need to look groups up by path.
+const groupTree = `
+	WITH RECURSIVE tree(id, name, parent_id, path) AS (
+		SELECT id, name, parent_id, name FROM groups WHERE parent_id IS NULL
+		UNION ALL
+		SELECT g.id, g.name, g.parent_id, tree.path || '` + models.GroupPathSeparator + `' || g.name
+		FROM groups g
+		JOIN tree ON g.parent_id = tree.id
+	)`
+
+// groupCondition returns a condition on projects p that matches the members
+// of the groups selected by value and of their subgroups. A value containing
+// the path separator is a path from the top level, as in Work/Clients; any
+// other value is a group name at any depth.
+func groupCondition(value string) (string, []any) {
+	column := "name"
+	if strings.Contains(value, models.GroupPathSeparator) {
+		column = "path"
+		value = strings.Trim(value, models.GroupPathSeparator)
+	}
+
+	return `p.id IN (` + groupTree + `,
+		selected(id) AS (
+			SELECT id FROM tree WHERE ` + column + ` = ? COLLATE NOCASE
+			UNION
+			SELECT g.id FROM groups g JOIN selected s ON g.parent_id = s.id
+		)
+		SELECT pg.project_id FROM project_groups pg WHERE pg.group_id IN selected
+	)`, []any{value}
+}
+
+// validateGroupName trims a group name and rejects names that cannot be
+// told apart in a group path.
+func validateGroupName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("group name cannot be empty")
+	}
+	if strings.Contains(name, models.GroupPathSeparator) {
+		return "", fmt.Errorf("group name cannot contain %q", models.GroupPathSeparator)
+	}
+	return name, nil
+}
+
+// nullID maps the zero ID to NULL for optional references.
+func nullID(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// requireGroup returns ErrNotFound unless the group exists.
+func requireGroup(ctx context.Context, q dbtx, id int64) error {
+	var exists bool
+	err := q.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM groups WHERE id = ?)", id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to look up group: %v", err)
+	}
+	if !exists {
+		return fmt.Errorf("group %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SQLiteProjectRepository) CreateGroup(ctx context.Context, group *models.Group) error {
+	name, err := validateGroupName(group.Name)
+	if err != nil {
+		return err
+	}
+
+	return r.inTx(ctx, func(tx dbtx) error {
+		if group.ParentID != 0 {
+			if err := requireGroup(ctx, tx, group.ParentID); err != nil {
+				return err
+			}
+		}
+
+		result, err := tx.ExecContext(ctx, "INSERT INTO groups (name, parent_id) VALUES (?, ?)", name, nullID(group.ParentID))
+		if err != nil {
+			return fmt.Errorf("failed to create group: %w", mapError(err))
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to read group id: %v", err)
+		}
+
+		stored, err := getGroup(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		*group = *stored
+		return nil
+	})
+}
+
+// EnsureGroupPath returns the group with the given path, creating it and
+// any missing ancestors.
+func (r *SQLiteProjectRepository) EnsureGroupPath(ctx context.Context, path string) (*models.Group, error) {
+	var group *models.Group
+
+	err := r.inTx(ctx, func(tx dbtx) error {
+		var parentID int64
+		for _, name := range strings.Split(strings.Trim(path, models.GroupPathSeparator), models.GroupPathSeparator) {
+			name, err := validateGroupName(name)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx,
+				"INSERT INTO groups (name, parent_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+				name,
+				nullID(parentID),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create group %s: %v", name, err)
+			}
+
+			err = tx.QueryRowContext(ctx,
+				"SELECT id FROM groups WHERE name = ? AND IFNULL(parent_id, 0) = ?",
+				name,
+				parentID,
+			).Scan(&parentID)
+			if err != nil {
+				return fmt.Errorf("failed to look up group %s: %v", name, err)
+			}
+		}
+
+		var err error
+		group, err = getGroup(ctx, tx, parentID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (r *SQLiteProjectRepository) RenameGroup(ctx context.Context, id int64, name string) error {
+	name, err := validateGroupName(name)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.conn().ExecContext(ctx, "UPDATE groups SET name = ? WHERE id = ?", name, id)
+	if err != nil {
+		return fmt.Errorf("failed to rename group: %w", mapError(err))
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %v", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("group %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SQLiteProjectRepository) MoveGroup(ctx context.Context, id, parentID int64) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		if err := requireGroup(ctx, tx, id); err != nil {
+			return err
+		}
+
+		if parentID != 0 {
+			if err := requireGroup(ctx, tx, parentID); err != nil {
+				return err
+			}
+
+			var cycle bool
+			err := tx.QueryRowContext(ctx, `
+				WITH RECURSIVE subtree(id) AS (
+					SELECT ?
+					UNION
+					SELECT g.id FROM groups g JOIN subtree s ON g.parent_id = s.id
+				)
+				SELECT EXISTS (SELECT 1 FROM subtree WHERE id = ?)
+			`, id, parentID).Scan(&cycle)
+			if err != nil {
+				return fmt.Errorf("failed to check group nesting: %v", err)
+			}
+			if cycle {
+				return ErrGroupCycle
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, "UPDATE groups SET parent_id = ? WHERE id = ?", nullID(parentID), id)
+		if err != nil {
+			return fmt.Errorf("failed to move group: %w", mapError(err))
+		}
+		return nil
+	})
+}
+
+// DeleteGroup removes a group together with its subgroups. The projects in
+// them are kept.
+func (r *SQLiteProjectRepository) DeleteGroup(ctx context.Context, id int64) error {
+	result, err := r.conn().ExecContext(ctx, "DELETE FROM groups WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete group: %v", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %v", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("group %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// ListGroups returns every group ordered by path, each directly followed by
+// its subgroups.
+func (r *SQLiteProjectRepository) ListGroups(ctx context.Context) ([]models.Group, error) {
+	return queryGroups(ctx, r.conn(), "1")
+}
+
+// ProjectGroups returns the groups a project belongs to, ordered by path.
+func (r *SQLiteProjectRepository) ProjectGroups(ctx context.Context, projectID int64) ([]models.Group, error) {
+	return queryGroups(ctx, r.conn(), "t.id IN (SELECT group_id FROM project_groups WHERE project_id = ?)", projectID)
+}
+
+func (r *SQLiteProjectRepository) AddProjectToGroup(ctx context.Context, projectID, groupID int64) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		if err := requireGroup(ctx, tx, groupID); err != nil {
+			return err
+		}
+
+		var exists bool
+		err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM projects WHERE id = ?)", projectID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to look up project: %v", err)
+		}
+		if !exists {
+			return fmt.Errorf("project %d: %w", projectID, ErrNotFound)
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO project_groups (project_id, group_id) VALUES (?, ?)",
+			projectID,
+			groupID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add project to group: %v", err)
+		}
+		return nil
+	})
+}
+
+func (r *SQLiteProjectRepository) RemoveProjectFromGroup(ctx context.Context, projectID, groupID int64) error {
+	_, err := r.conn().ExecContext(ctx,
+		"DELETE FROM project_groups WHERE project_id = ? AND group_id = ?",
+		projectID,
+		groupID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove project from group: %v", err)
+	}
+	return nil
+}
+
+// getGroup returns a single group with its path.
+func getGroup(ctx context.Context, q dbtx, id int64) (*models.Group, error) {
+	groups, err := queryGroups(ctx, q, "t.id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("group %d: %w", id, ErrNotFound)
+	}
+	return &groups[0], nil
+}
+
+// queryGroups returns the groups matching an SQL condition on the group
+// tree aliased as t, ordered by path. The separator sorts before any other
+// character so that subgroups directly follow their parent, even if a
+// sibling of the parent shares its name as a prefix.
+func queryGroups(ctx context.Context, q dbtx, condition string, args ...any) ([]models.Group, error) {
+	query := groupTree + `
+		SELECT t.id, t.name, IFNULL(t.parent_id, 0), t.path, (
+			SELECT COUNT(*)
+			FROM project_groups pg
+			JOIN projects p ON p.id = pg.project_id
+			WHERE pg.group_id = t.id AND p.deleted_at IS NULL
+		)
+		FROM tree t
+		WHERE ` + condition + `
+		ORDER BY REPLACE(t.path, '` + models.GroupPathSeparator + `', char(1)) COLLATE NOCASE
+	`
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query groups: %v", err)
+	}
+	defer rows.Close()
+
+	var groups []models.Group
+	for rows.Next() {
+		var group models.Group
+		if err := rows.Scan(&group.ID, &group.Name, &group.ParentID, &group.Path, &group.ProjectCount); err != nil {
+			return nil, fmt.Errorf("failed to scan group: %v", err)
+		}
+		groups = append(groups, group)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading groups: %v", err)
+	}
+
+	return groups, nil
+}
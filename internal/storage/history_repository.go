@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func (r *SQLiteProjectRepository) RecordChanges(ctx context.Context, projectID int64, changes []models.FieldChange, changedAt time.Time) (int, error) {
+	var revision int
+
+	err := r.inTx(ctx, func(tx dbtx) error {
+		err := tx.QueryRowContext(ctx,
+			"SELECT COALESCE(MAX(revision), 0) + 1 FROM project_history WHERE project_id = ?",
+			projectID,
+		).Scan(&revision)
+		if err != nil {
+			return fmt.Errorf("failed to read latest revision: %v", err)
+		}
+
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO project_history (project_id, revision, field, old_value, new_value, changed_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to prepare history statement: %v", err)
+		}
+		defer stmt.Close()
+
+		for _, change := range changes {
+			_, err = stmt.ExecContext(ctx, projectID, revision, change.Field, change.OldValue, change.NewValue, changedAt)
+			if err != nil {
+				return fmt.Errorf("failed to record change of %s: %v", change.Field, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revision, nil
+}
+
+// ListHistory returns the revisions of a project, newest first.
+func (r *SQLiteProjectRepository) ListHistory(ctx context.Context, projectID int64) ([]models.ProjectRevision, error) {
+	query := `
+		SELECT revision, field, old_value, new_value, changed_at
+		FROM project_history
+		WHERE project_id = ?
+		ORDER BY revision DESC, id
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query project history: %v", err)
+	}
+	defer rows.Close()
+
+	var revisions []models.ProjectRevision
+	for rows.Next() {
+		var revision int
+		var change models.FieldChange
+		var changedAt time.Time
+		if err := rows.Scan(&revision, &change.Field, &change.OldValue, &change.NewValue, &changedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan project history: %v", err)
+		}
+
+		if len(revisions) == 0 || revisions[len(revisions)-1].Revision != revision {
+			revisions = append(revisions, models.ProjectRevision{
+				ProjectID: projectID,
+				Revision:  revision,
+				ChangedAt: changedAt,
+			})
+		}
+		last := &revisions[len(revisions)-1]
+		last.Changes = append(last.Changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading project history: %v", err)
+	}
+
+	return revisions, nil
+}
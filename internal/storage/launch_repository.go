@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// periodFormats maps a statistics period to the strftime format of its label.
+var periodFormats = map[models.StatsPeriod]string{
+	models.StatsByDay:  "%Y-%m-%d",
+	models.StatsByWeek: "%G-W%V",
+}
+
+func (r *SQLiteProjectRepository) RecordLaunch(ctx context.Context, projectID int64, editor string, launchedAt time.Time) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO launches (project_id, editor, launched_at) VALUES (?, ?, ?)",
+			projectID,
+			editor,
+			launchedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record launch: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE projects SET last_opened = ? WHERE id = ?", launchedAt, projectID)
+		if err != nil {
+			return fmt.Errorf("failed to update last opened time: %v", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *SQLiteProjectRepository) ListLaunches(ctx context.Context, since time.Time) ([]models.Launch, error) {
+	query := `
+		SELECT project_id, editor, launched_at
+		FROM launches
+		WHERE julianday(launched_at) >= julianday(?)
+		ORDER BY launched_at
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query launches: %v", err)
+	}
+	defer rows.Close()
+
+	var launches []models.Launch
+	for rows.Next() {
+		var launch models.Launch
+		if err := rows.Scan(&launch.ProjectID, &launch.Editor, &launch.LaunchedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan launch: %v", err)
+		}
+		launches = append(launches, launch)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading launches: %v", err)
+	}
+
+	return launches, nil
+}
+
+func (r *SQLiteProjectRepository) LaunchCounts(ctx context.Context, period models.StatsPeriod, since time.Time) ([]models.LaunchCount, error) {
+	format, ok := periodFormats[period]
+	if !ok {
+		return nil, fmt.Errorf("unsupported statistics period: %s", period)
+	}
+
+	query := `
+		SELECT strftime(?, launched_at, 'localtime') AS period, COUNT(*)
+		FROM launches
+		WHERE julianday(launched_at) >= julianday(?)
+		GROUP BY period
+		ORDER BY period
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query, format, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query launch counts: %v", err)
+	}
+	defer rows.Close()
+
+	var counts []models.LaunchCount
+	for rows.Next() {
+		var count models.LaunchCount
+		if err := rows.Scan(&count.Period, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan launch count: %v", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading launch counts: %v", err)
+	}
+
+	return counts, nil
+}
+
+func (r *SQLiteProjectRepository) ProjectUsage(ctx context.Context, since time.Time, limit int) ([]models.ProjectUsage, error) {
+	query := `
+		SELECT ` + projectColumns + `,
+		       COUNT(*) AS launch_count, MAX(julianday(l.launched_at)) AS last_launch
+		FROM launches l
+		JOIN projects p ON p.id = l.project_id
+		WHERE julianday(l.launched_at) >= julianday(?) AND p.deleted_at IS NULL
+		GROUP BY p.id
+		ORDER BY launch_count DESC, last_launch DESC
+	`
+	args := []any{since}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query project usage: %v", err)
+	}
+	defer rows.Close()
+
+	var usage []models.ProjectUsage
+	for rows.Next() {
+		var entry models.ProjectUsage
+		var lastLaunch float64
+		var err error
+
+		entry.Project, err = scanProject(rows, &entry.Count, &lastLaunch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project usage: %v", err)
+		}
+
+		entry.LastLaunched = julianDayToTime(lastLaunch)
+		usage = append(usage, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading project usage: %v", err)
+	}
+
+	return usage, nil
+}
+
+// julianDayToTime converts an SQLite julian day number into a local time.
+func julianDayToTime(day float64) time.Time {
+	const unixEpochJulianDay = 2440587.5
+	seconds := (day - unixEpochJulianDay) * 86400
+	return time.Unix(0, int64(seconds*float64(time.Second))).Local()
+}
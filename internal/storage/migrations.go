@@ -0,0 +1,497 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// ErrSchemaTooNew is returned when the database was written by a newer
+// version of the application than the one currently running.
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of ProjectManager")
+
+// migration describes a single, ordered step of the database schema.
+// Versions must be strictly increasing and must never be reused once released.
+type migration struct {
+	version     int
+	description string
+	up          func(ctx context.Context, tx *sql.Tx) error
+}
+
+// migrations lists every schema change in the order it has to be applied.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create projects table",
+		up: execStatements(`
+			CREATE TABLE IF NOT EXISTS projects (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL,
+				path TEXT NOT NULL UNIQUE,
+				description TEXT,
+				readme_path TEXT,
+				last_opened DATETIME,
+				tags TEXT,
+				icon TEXT
+			)
+		`),
+	},
+	{
+		version:     2,
+		description: "move tags into tags and project_tags tables",
+		up:          migrateTagsToTables,
+	},
+	{
+		version:     3,
+		description: "create full-text search index",
+		up:          createSearchIndex,
+	},
+	{
+		version:     4,
+		description: "create launches table",
+		up: execStatements(`
+			CREATE TABLE launches (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+				launched_at DATETIME NOT NULL
+			)
+		`, `
+			CREATE INDEX idx_launches_project_id ON launches(project_id, launched_at)
+		`),
+	},
+	{
+		version:     5,
+		description: "record the editor used for each launch",
+		up: execStatements(`
+			ALTER TABLE launches ADD COLUMN editor TEXT NOT NULL DEFAULT ''
+		`, `
+			CREATE INDEX idx_launches_launched_at ON launches(launched_at)
+		`),
+	},
+	{
+		version:     6,
+		description: "add deleted_at to projects for the trash",
+		up: execStatements(`
+			ALTER TABLE projects ADD COLUMN deleted_at DATETIME
+		`),
+	},
+	{
+		version:     7,
+		description: "create project_history table",
+		up: execStatements(`
+			CREATE TABLE project_history (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+				revision INTEGER NOT NULL,
+				field TEXT NOT NULL,
+				old_value TEXT NOT NULL,
+				new_value TEXT NOT NULL,
+				changed_at DATETIME NOT NULL
+			)
+		`, `
+			CREATE INDEX idx_project_history_project_id ON project_history(project_id, revision)
+		`),
+	},
+	{
+		version:     8,
+		description: "add created_at to projects",
+		up:          addCreatedAt,
+	},
+	{
+		version:     9,
+		description: "remember the git root commit of projects",
+		up: execStatements(`
+			ALTER TABLE projects ADD COLUMN git_root_commit TEXT NOT NULL DEFAULT ''
+		`),
+	},
+	{
+		version:     10,
+		description: "create project_fields table for custom fields",
+		up: execStatements(`
+			CREATE TABLE project_fields (
+				project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+				name TEXT NOT NULL COLLATE NOCASE,
+				value TEXT NOT NULL,
+				PRIMARY KEY (project_id, name)
+			)
+		`, `
+			CREATE INDEX idx_project_fields_name ON project_fields(name, value)
+		`),
+	},
+	{
+		version:     11,
+		description: "create groups and project_groups tables",
+		up: execStatements(`
+			CREATE TABLE groups (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL COLLATE NOCASE,
+				parent_id INTEGER REFERENCES groups(id) ON DELETE CASCADE
+			)
+		`, `
+			CREATE UNIQUE INDEX idx_groups_parent_name ON groups(IFNULL(parent_id, 0), name)
+		`, `
+			CREATE TABLE project_groups (
+				project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+				group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+				PRIMARY KEY (project_id, group_id)
+			)
+		`, `
+			CREATE INDEX idx_project_groups_group_id ON project_groups(group_id)
+		`),
+	},
+	{
+		version:     12,
+		description: "add pinned_position to projects",
+		up: execStatements(`
+			ALTER TABLE projects ADD COLUMN pinned_position INTEGER
+		`),
+	},
+	{
+		version:     13,
+		description: "add project status and status history",
+		up: execStatements(`
+			ALTER TABLE projects ADD COLUMN status TEXT NOT NULL DEFAULT 'active'
+		`, `
+			CREATE TABLE project_status_history (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+				from_status TEXT NOT NULL,
+				to_status TEXT NOT NULL,
+				changed_at DATETIME NOT NULL
+			)
+		`, `
+			CREATE INDEX idx_project_status_history_project_id ON project_status_history(project_id, changed_at)
+		`, `
+			INSERT INTO project_status_history (project_id, from_status, to_status, changed_at)
+			SELECT id, '', status, created_at FROM projects
+		`),
+	},
+	{
+		version:     14,
+		description: "create tables caching the git status of projects",
+		up: execStatements(`
+			CREATE TABLE project_git_status (
+				project_id INTEGER PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+				branch TEXT NOT NULL,
+				upstream TEXT NOT NULL,
+				ahead INTEGER NOT NULL,
+				behind INTEGER NOT NULL,
+				staged INTEGER NOT NULL,
+				modified INTEGER NOT NULL,
+				untracked INTEGER NOT NULL,
+				conflicted INTEGER NOT NULL,
+				last_commit_at DATETIME,
+				last_commit_author TEXT NOT NULL,
+				checked_at DATETIME NOT NULL
+			)
+		`, `
+			CREATE TABLE project_git_remotes (
+				project_id INTEGER NOT NULL REFERENCES project_git_status(project_id) ON DELETE CASCADE,
+				name TEXT NOT NULL,
+				url TEXT NOT NULL,
+				PRIMARY KEY (project_id, name)
+			)
+		`),
+	},
+	{
+		version:     15,
+		description: "create tables for the code statistics of projects",
+		up: execStatements(`
+			CREATE TABLE project_code_stats (
+				project_id INTEGER PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+				analyzed_at DATETIME NOT NULL
+			)
+		`, `
+			CREATE TABLE project_languages (
+				project_id INTEGER NOT NULL REFERENCES project_code_stats(project_id) ON DELETE CASCADE,
+				language TEXT NOT NULL,
+				files INTEGER NOT NULL,
+				code INTEGER NOT NULL,
+				comments INTEGER NOT NULL,
+				blanks INTEGER NOT NULL,
+				PRIMARY KEY (project_id, language)
+			)
+		`),
+	},
+	{
+		version:     16,
+		description: "create tables for the dependencies declared in project manifests",
+		up: execStatements(`
+			CREATE TABLE project_dependency_scans (
+				project_id INTEGER PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+				scanned_at DATETIME NOT NULL
+			)
+		`, `
+			CREATE TABLE project_dependencies (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				project_id INTEGER NOT NULL REFERENCES project_dependency_scans(project_id) ON DELETE CASCADE,
+				ecosystem TEXT NOT NULL,
+				name TEXT NOT NULL,
+				version TEXT NOT NULL,
+				scope TEXT NOT NULL,
+				manifest TEXT NOT NULL,
+				indirect BOOLEAN NOT NULL DEFAULT 0
+			)
+		`, `
+			CREATE INDEX idx_project_dependencies_project ON project_dependencies(project_id)
+		`),
+	},
+}
+
+// execStatements builds a migration step that runs the given SQL statements in order.
+func execStatements(statements ...string) func(ctx context.Context, tx *sql.Tx) error {
+	return func(ctx context.Context, tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// migrateTagsToTables replaces the comma-joined projects.tags column with a
+// normalized many-to-many relation between projects and tags.
+func migrateTagsToTables(ctx context.Context, tx *sql.Tx) error {
+	err := execStatements(`
+		CREATE TABLE tags (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE
+		)
+	`, `
+		CREATE TABLE project_tags (
+			project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+			tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+			PRIMARY KEY (project_id, tag_id)
+		)
+	`, `
+		CREATE INDEX idx_project_tags_tag_id ON project_tags(tag_id)
+	`)(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id, tags FROM projects WHERE tags IS NOT NULL AND tags != ''")
+	if err != nil {
+		return err
+	}
+
+	projectTags := make(map[int64][]string)
+	for rows.Next() {
+		var id int64
+		var tagsStr string
+		if err := rows.Scan(&id, &tagsStr); err != nil {
+			rows.Close()
+			return err
+		}
+		projectTags[id] = strings.Split(tagsStr, ",")
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, tags := range projectTags {
+		if err := setProjectTags(ctx, tx, id, tags); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "ALTER TABLE projects DROP COLUMN tags")
+	return err
+}
+
+// createSearchIndex creates the FTS5 index over projects and fills it with
+// the existing rows, including the text of linked README files.
+func createSearchIndex(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE VIRTUAL TABLE projects_fts USING fts5(
+			name, description, path, tags, readme,
+			tokenize = 'unicode61 remove_diacritics 2'
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id, name, path, description, readme_path FROM projects")
+	if err != nil {
+		return err
+	}
+
+	var projects []models.Project
+	for rows.Next() {
+		var project models.Project
+		var description, readmePath sql.NullString
+		if err := rows.Scan(&project.ID, &project.Name, &project.Path, &description, &readmePath); err != nil {
+			rows.Close()
+			return err
+		}
+		project.Description = description.String
+		project.ReadmePath = readmePath.String
+		projects = append(projects, project)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range projects {
+		projects[i].Tags, err = getProjectTags(ctx, tx, projects[i].ID)
+		if err != nil {
+			return err
+		}
+		if err := indexProject(ctx, tx, &projects[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// latestSchemaVersion returns the version the database will have after all migrations ran.
+func latestSchemaVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].version
+}
+
+// addCreatedAt adds the creation time of projects. It is unknown for existing
+// projects, which get the time of the migration; sorting by it breaks ties
+// by id, which keeps them in the order they were added.
+func addCreatedAt(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, "ALTER TABLE projects ADD COLUMN created_at DATETIME")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE projects SET created_at = ?", time.Now())
+	return err
+}
+
+// migrate brings the database schema up to date. It refuses to touch a database
+// created by a newer binary and backs up existing data before upgrading it.
+func migrate(ctx context.Context, db *sql.DB, dbPath string) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			description TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_version table: %v", err)
+	}
+
+	current, err := currentSchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	latest := latestSchemaVersion()
+	if current > latest {
+		return fmt.Errorf("%w: database is at version %d, this binary supports up to %d", ErrSchemaTooNew, current, latest)
+	}
+	if current == latest {
+		return nil
+	}
+
+	hasData, err := hasUserTables(ctx, db)
+	if err != nil {
+		return err
+	}
+	if hasData {
+		backupPath := fmt.Sprintf("%s.v%d-%s.bak", dbPath, current, time.Now().Format("20060102-150405"))
+		if err := backupDatabase(ctx, db, backupPath); err != nil {
+			return fmt.Errorf("failed to back up database before migration: %v", err)
+		}
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(ctx, db, m); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %v", m.version, m.description, err)
+		}
+	}
+
+	return nil
+}
+
+// currentSchemaVersion returns the highest applied migration version, or 0 for a fresh database.
+func currentSchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version sql.NullInt64
+	err := db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_version").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %v", err)
+	}
+	return int(version.Int64), nil
+}
+
+// hasUserTables reports whether the database contains any tables besides schema_version.
+func hasUserTables(ctx context.Context, db *sql.DB) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'table' AND name NOT IN ('schema_version', 'sqlite_sequence')
+	`).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect database: %v", err)
+	}
+	return count > 0, nil
+}
+
+// applyMigration runs a single migration and records it in one transaction,
+// so a failing step leaves the database at the previous version.
+func applyMigration(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := beginTx(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Another process may have applied the migration while this one waited
+	// for the write lock.
+	var applied bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM schema_version WHERE version = ?)", m.version).Scan(&applied)
+	if err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	if err := m.up(ctx, tx); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO schema_version (version, description, applied_at) VALUES (?, ?, ?)",
+		m.version,
+		m.description,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// backupDatabase writes a consistent copy of the database to backupPath.
+func backupDatabase(ctx context.Context, db *sql.DB, backupPath string) error {
+	if _, err := os.Stat(backupPath); err == nil {
+		return fmt.Errorf("backup file already exists: %s", backupPath)
+	}
+
+	_, err := db.ExecContext(ctx, "VACUUM INTO ?", backupPath)
+	return err
+}
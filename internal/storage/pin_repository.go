@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// pinnedIDs returns the IDs of the pinned projects outside the trash in pin
+// order, leaving out the project with the ID exclude.
+func pinnedIDs(ctx context.Context, q dbtx, exclude int64) ([]int64, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id FROM projects
+		WHERE pinned_position IS NOT NULL AND deleted_at IS NULL AND id != ?
+		ORDER BY pinned_position, id
+	`, exclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pinned projects: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan pinned project: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// setPinOrder numbers the given projects 1, 2, ... in order.
+func setPinOrder(ctx context.Context, q dbtx, ids []int64) error {
+	for i, id := range ids {
+		_, err := q.ExecContext(ctx, "UPDATE projects SET pinned_position = ? WHERE id = ?", i+1, id)
+		if err != nil {
+			return fmt.Errorf("failed to reorder pinned projects: %v", err)
+		}
+	}
+	return nil
+}
+
+// Pin pins a project at the given 1-based position, moving it there if it is
+// already pinned. Positions outside the pinned list append the project.
+func (r *SQLiteProjectRepository) Pin(ctx context.Context, id int64, position int) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		var trashed bool
+		err := tx.QueryRowContext(ctx, "SELECT deleted_at IS NOT NULL FROM projects WHERE id = ?", id).Scan(&trashed)
+		if err != nil {
+			return fmt.Errorf("project %d: %w", id, mapError(err))
+		}
+		if trashed {
+			return fmt.Errorf("project %d is in the trash", id)
+		}
+
+		ids, err := pinnedIDs(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		index := position - 1
+		if position <= 0 || index > len(ids) {
+			index = len(ids)
+		}
+
+		return setPinOrder(ctx, tx, slices.Insert(ids, index, id))
+	})
+}
+
+func (r *SQLiteProjectRepository) Unpin(ctx context.Context, id int64) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE projects SET pinned_position = NULL WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to unpin project: %v", err)
+		}
+
+		err = expectAffected(result, id)
+		if err != nil {
+			return err
+		}
+
+		ids, err := pinnedIDs(ctx, tx, 0)
+		if err != nil {
+			return err
+		}
+		return setPinOrder(ctx, tx, ids)
+	})
+}
+
+// ListPinned returns the pinned projects in pin order.
+func (r *SQLiteProjectRepository) ListPinned(ctx context.Context) ([]models.Project, error) {
+	return r.listProjects(ctx, "p.pinned_position IS NOT NULL AND p.deleted_at IS NULL ORDER BY p.pinned_position, p.id")
+}
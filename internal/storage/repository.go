@@ -1,135 +1,767 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/query"
 )
 
 type ProjectRepository interface {
-	Create(project *models.Project) error
-	Update(project *models.Project) error
-	Delete(id int64) error
-	GetByID(id int64) (*models.Project, error)
-	ListAll() ([]models.Project, error)
+	Create(ctx context.Context, project *models.Project) error
+	// CreateMany inserts all projects in a single transaction; if one of
+	// them fails, none are stored.
+	CreateMany(ctx context.Context, projects []*models.Project) error
+	// CreateOrUpdateByPath inserts the project, or, if a project with the
+	// same path exists, refreshes its README link and tags from the given
+	// project and loads the stored project into it. It reports whether a
+	// new project was created.
+	CreateOrUpdateByPath(ctx context.Context, project *models.Project) (bool, error)
+	Update(ctx context.Context, project *models.Project) error
+	GetByID(ctx context.Context, id int64) (*models.Project, error)
+	GetByPath(ctx context.Context, path string) (*models.Project, error)
+	// Relocate changes the path of a project; Update leaves the path alone.
+	Relocate(ctx context.Context, id int64, path string) error
+	SetGitRootCommit(ctx context.Context, id int64, commit string) error
+	// SetStatus changes the status of a project and records the transition;
+	// Update leaves the status alone.
+	SetStatus(ctx context.Context, id int64, status models.Status, changedAt time.Time) error
+	// ListStatusHistory returns the status transitions of a project, newest first.
+	ListStatusHistory(ctx context.Context, projectID int64) ([]models.StatusChange, error)
+
+	// Pinned projects: Pin inserts a project at a 1-based position of the
+	// pinned list, or moves it there, and renumbers the others.
+	Pin(ctx context.Context, id int64, position int) error
+	Unpin(ctx context.Context, id int64) error
+	ListPinned(ctx context.Context) ([]models.Project, error)
+	List(ctx context.Context, opts models.ListOptions) ([]models.Project, error)
+	// Count returns the number of projects List would return without limit and offset.
+	Count(ctx context.Context, opts models.ListOptions) (int, error)
+
+	// Trash: Delete only moves a project to the trash, and unpins it; Purge
+	// removes it for good.
+	Delete(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) error
+	Purge(ctx context.Context, id int64) error
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) ([]int64, error)
+	ListTrash(ctx context.Context) ([]models.Project, error)
+	Search(ctx context.Context, q *query.Query) ([]models.SearchResult, error)
+
+	// Launch history
+	RecordLaunch(ctx context.Context, projectID int64, editor string, launchedAt time.Time) error
+	ListLaunches(ctx context.Context, since time.Time) ([]models.Launch, error)
+	LaunchCounts(ctx context.Context, period models.StatsPeriod, since time.Time) ([]models.LaunchCount, error)
+	ProjectUsage(ctx context.Context, since time.Time, limit int) ([]models.ProjectUsage, error)
+
+	// Change history: RecordChanges stores the changes as the next revision
+	// of the project and returns its number.
+	RecordChanges(ctx context.Context, projectID int64, changes []models.FieldChange, changedAt time.Time) (int, error)
+	ListHistory(ctx context.Context, projectID int64) ([]models.ProjectRevision, error)
+
+	// Tag management across all projects. The changes return the IDs of
+	// the projects whose tags changed.
+	ListTags(ctx context.Context) ([]models.Tag, error)
+	RenameTag(ctx context.Context, oldName, newName string) ([]int64, error)
+	MergeTags(ctx context.Context, sources []string, target string) ([]int64, error)
+	DeleteTag(ctx context.Context, name string) ([]int64, error)
+
+	// Git status cache: SetGitStatus replaces the cached status of a
+	// project, DeleteGitStatus drops it once the project is no longer a
+	// repository.
+	SetGitStatus(ctx context.Context, status *models.GitStatus) error
+	DeleteGitStatus(ctx context.Context, projectID int64) error
+	GetGitStatus(ctx context.Context, projectID int64) (*models.GitStatus, error)
+	ListGitStatuses(ctx context.Context) ([]models.GitStatus, error)
+
+	// Code statistics: SetCodeStats replaces the stored analysis of a project.
+	SetCodeStats(ctx context.Context, stats *models.CodeStats) error
+	GetCodeStats(ctx context.Context, projectID int64) (*models.CodeStats, error)
+
+	// Dependencies: SetDependencies replaces the stored manifest scan of a project.
+	SetDependencies(ctx context.Context, deps *models.ProjectDependencies) error
+	GetDependencies(ctx context.Context, projectID int64) (*models.ProjectDependencies, error)
+
+	// Groups: a project can belong to several groups, and groups nest.
+	CreateGroup(ctx context.Context, group *models.Group) error
+	// EnsureGroupPath returns the group with the given path, such as
+	// Work/Clients, creating it and its ancestors as needed.
+	EnsureGroupPath(ctx context.Context, path string) (*models.Group, error)
+	RenameGroup(ctx context.Context, id int64, name string) error
+	// MoveGroup gives a group a new parent; parentID 0 moves it to the top level.
+	MoveGroup(ctx context.Context, id, parentID int64) error
+	DeleteGroup(ctx context.Context, id int64) error
+	ListGroups(ctx context.Context) ([]models.Group, error)
+	ProjectGroups(ctx context.Context, projectID int64) ([]models.Group, error)
+	AddProjectToGroup(ctx context.Context, projectID, groupID int64) error
+	RemoveProjectFromGroup(ctx context.Context, projectID, groupID int64) error
+
+	// WithTx runs fn with a repository whose operations all belong to one
+	// transaction. The transaction is committed if fn returns nil and rolled
+	// back otherwise. Calling WithTx on a repository that is already part of
+	// a transaction joins it.
+	WithTx(ctx context.Context, fn func(repo ProjectRepository) error) error
 }
 
-func (r *SQLiteProjectRepository) Create(project *models.Project) error {
+// dbtx is implemented by both *sql.DB and *sql.Tx.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
 
-	query := `
- 		INSERT INTO projects
-		(name, path, description, readme_path, last_opened, tags, icon)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`
+type SQLiteProjectRepository struct {
+	db *sql.DB
+	// tx is set for repositories handed out by WithTx.
+	tx *sql.Tx
+}
 
-	tagsStr := strings.Join(project.Tags, ",")
+func NewProjectRepository(storage *SQLiteStorage) ProjectRepository {
+	return &SQLiteProjectRepository{db: storage.db}
+}
 
-	result, err := r.db.Exec(
-		query,
-		project.Name,
-		project.Path,
-		project.Description,
-		project.ReadmePath,
-		project.LastOpened,
-		tagsStr,
-		project.Icon,
-	)
+// expectAffected returns ErrNotFound if a statement on a single project did not change any row.
+func expectAffected(result sql.Result, id int64) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %v", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("project %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
 
+// conn returns the transaction the repository is bound to, or the database.
+func (r *SQLiteProjectRepository) conn() dbtx {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+func (r *SQLiteProjectRepository) WithTx(ctx context.Context, fn func(repo ProjectRepository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
-		return fmt.Errorf("failed to insert project: %v", err)
+		return fmt.Errorf("failed to begin transaction: %v", err)
 	}
+	defer tx.Rollback()
 
-	id, err := result.LastInsertId()
+	err = fn(&SQLiteProjectRepository{db: r.db, tx: tx})
 	if err != nil {
-		return fmt.Errorf("failed to get last insert ID: %v", err)
+		return err
 	}
 
-	project.ID = id
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
 
 	return nil
 }
 
-func (r *SQLiteProjectRepository) Update(project *models.Project) error {
-	query := `
-		UPDATE projects
-		SET name = ?, description = ?, readme_path = ?, last_opened = ?, tags = ?, icon = ?
-		WHERE id = ?
-	`
+// inTx runs fn inside the repository's transaction, starting a new one if
+// the repository is not bound to a transaction yet.
+func (r *SQLiteProjectRepository) inTx(ctx context.Context, fn func(tx dbtx) error) error {
+	return r.WithTx(ctx, func(repo ProjectRepository) error {
+		return fn(repo.(*SQLiteProjectRepository).tx)
+	})
+}
 
-	tagsStr := strings.Join(project.Tags, ",")
+const insertProjectQuery = `
+	INSERT INTO projects
+	(name, path, description, readme_path, last_opened, icon, created_at, deleted_at, git_root_commit, pinned_position, status)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+// insertProject stores a project, its tags and its search index entry
+// using the given prepared insert statement, and sets the project's ID.
+func insertProject(ctx context.Context, tx dbtx, stmt *sql.Stmt, project *models.Project) error {
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = time.Now()
+	}
+	if project.Status == "" {
+		project.Status = models.DefaultStatus
+	}
 
-	_, err := r.db.Exec(
-		query,
+	result, err := stmt.ExecContext(
+		ctx,
 		project.Name,
+		project.Path,
 		project.Description,
 		project.ReadmePath,
 		project.LastOpened,
-		tagsStr,
 		project.Icon,
-		project.ID,
+		project.CreatedAt,
+		nullTime(project.DeletedAt),
+		project.GitRootCommit,
+		nullPosition(project.PinnedPosition),
+		project.Status,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to insert project: %w", mapError(err))
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %v", err)
+	}
+
+	err = setProjectTags(ctx, tx, id, project.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to save project tags: %v", err)
+	}
+
+	err = setProjectFields(ctx, tx, id, project.CustomFields)
+	if err != nil {
+		return fmt.Errorf("failed to save project fields: %v", err)
+	}
 
+	err = recordStatus(ctx, tx, id, "", project.Status, project.CreatedAt)
 	if err != nil {
-		return fmt.Errorf("failed to update project: %v", err)
+		return fmt.Errorf("failed to record project status: %v", err)
 	}
 
+	indexed := *project
+	indexed.ID = id
+	err = indexProject(ctx, tx, &indexed)
+	if err != nil {
+		return fmt.Errorf("failed to index project: %v", err)
+	}
+
+	project.ID = id
+
 	return nil
 }
 
-func (r *SQLiteProjectRepository) Delete(id int64) error {
-	query := `
-		DELETE FROM projects
-		WHERE id = ?
-	`
+func (r *SQLiteProjectRepository) Create(ctx context.Context, project *models.Project) error {
+	return r.CreateMany(ctx, []*models.Project{project})
+}
 
-	_, err := r.db.Exec(query, id)
+func (r *SQLiteProjectRepository) CreateMany(ctx context.Context, projects []*models.Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(projects))
+	for i, project := range projects {
+		ids[i] = project.ID
+	}
+
+	err := r.inTx(ctx, func(tx dbtx) error {
+		stmt, err := tx.PrepareContext(ctx, insertProjectQuery)
+		if err != nil {
+			return fmt.Errorf("failed to prepare insert: %v", err)
+		}
+		defer stmt.Close()
+
+		for _, project := range projects {
+			if err := insertProject(ctx, tx, stmt, project); err != nil {
+				return fmt.Errorf("%s: %w", project.Path, err)
+			}
+		}
+
+		return nil
+	})
+
+	// IDs handed out inside a rolled back transaction are not valid.
 	if err != nil {
-		return fmt.Errorf("failed to delete project: %v", err)
+		for i, project := range projects {
+			project.ID = ids[i]
+		}
 	}
 
-	return nil
+	return err
+}
+
+func (r *SQLiteProjectRepository) CreateOrUpdateByPath(ctx context.Context, project *models.Project) (bool, error) {
+	created := false
+
+	err := r.WithTx(ctx, func(repo ProjectRepository) error {
+		tx := repo.(*SQLiteProjectRepository).tx
+
+		var existingID int64
+		err := tx.QueryRowContext(ctx, "SELECT id FROM projects WHERE path = ?", project.Path).Scan(&existingID)
+		if err == sql.ErrNoRows {
+			created = true
+			return repo.Create(ctx, project)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up project by path: %v", err)
+		}
+
+		existing, err := repo.GetByID(ctx, existingID)
+		if err != nil {
+			return err
+		}
+
+		// Name, description and last opened time belong to the user; only
+		// the metadata detected from the folder is refreshed.
+		if project.ReadmePath != "" {
+			existing.ReadmePath = project.ReadmePath
+		}
+		existing.Tags = append(existing.Tags, project.Tags...)
+
+		err = repo.Update(ctx, existing)
+		if err != nil {
+			return err
+		}
+
+		updated, err := repo.GetByID(ctx, existingID)
+		if err != nil {
+			return err
+		}
+
+		*project = *updated
+		return nil
+	})
+
+	return created, err
 }
 
-func (r *SQLiteProjectRepository) GetByID(id int64) (*models.Project, error) {
+func (r *SQLiteProjectRepository) Update(ctx context.Context, project *models.Project) error {
 	query := `
-		SELECT id, name, path, description, readme_path, last_opened, tags, icon
-		FROM projects
+		UPDATE projects
+		SET name = ?, description = ?, readme_path = ?, last_opened = ?, icon = ?
 		WHERE id = ?
 	`
 
+	return r.inTx(ctx, func(tx dbtx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			query,
+			project.Name,
+			project.Description,
+			project.ReadmePath,
+			project.LastOpened,
+			project.Icon,
+			project.ID,
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to update project: %v", err)
+		}
+
+		err = expectAffected(result, project.ID)
+		if err != nil {
+			return err
+		}
+
+		err = setProjectTags(ctx, tx, project.ID, project.Tags)
+		if err != nil {
+			return fmt.Errorf("failed to save project tags: %v", err)
+		}
+
+		err = setProjectFields(ctx, tx, project.ID, project.CustomFields)
+		if err != nil {
+			return fmt.Errorf("failed to save project fields: %v", err)
+		}
+
+		// The path is not part of the update, so index the stored one.
+		indexed := *project
+		err = tx.QueryRowContext(ctx, "SELECT path FROM projects WHERE id = ?", project.ID).Scan(&indexed.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read project path: %v", err)
+		}
+
+		err = indexProject(ctx, tx, &indexed)
+		if err != nil {
+			return fmt.Errorf("failed to index project: %v", err)
+		}
+
+		err = deleteUnusedTags(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to clean up tags: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// projectColumns lists the columns read by scanProject, for a query on
+// the projects table aliased as p.
+const projectColumns = `
+	p.id, p.name, p.path, COALESCE(p.description, ''), COALESCE(p.readme_path, ''),
+	p.last_opened, COALESCE(p.icon, ''), p.created_at, p.deleted_at, p.git_root_commit,
+	COALESCE(p.pinned_position, 0), p.status`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanProject reads the projectColumns of a row, followed by any extra columns.
+func scanProject(row rowScanner, extra ...any) (models.Project, error) {
 	var project models.Project
-	var tagsStr string
+	var lastOpened, createdAt, deletedAt sql.NullTime
 
-	err := r.db.QueryRow(query, id).Scan(
+	dest := append([]any{
 		&project.ID,
 		&project.Name,
 		&project.Path,
 		&project.Description,
 		&project.ReadmePath,
-		&project.LastOpened,
-		&tagsStr,
+		&lastOpened,
 		&project.Icon,
-	)
+		&createdAt,
+		&deletedAt,
+		&project.GitRootCommit,
+		&project.PinnedPosition,
+		&project.Status,
+	}, extra...)
+
+	err := row.Scan(dest...)
+	project.LastOpened = lastOpened.Time
+	project.CreatedAt = createdAt.Time
+	project.DeletedAt = deletedAt.Time
+
+	return project, err
+}
+
+func (r *SQLiteProjectRepository) Relocate(ctx context.Context, id int64, path string) error {
+	return r.WithTx(ctx, func(repo ProjectRepository) error {
+		tx := repo.(*SQLiteProjectRepository).tx
+
+		result, err := tx.ExecContext(ctx, "UPDATE projects SET path = ? WHERE id = ?", path, id)
+		if err != nil {
+			return fmt.Errorf("failed to relocate project: %w", mapError(err))
+		}
+
+		err = expectAffected(result, id)
+		if err != nil {
+			return err
+		}
+
+		relocated, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = indexProject(ctx, tx, relocated)
+		if err != nil {
+			return fmt.Errorf("failed to index project: %v", err)
+		}
 
+		return nil
+	})
+}
+
+func (r *SQLiteProjectRepository) SetGitRootCommit(ctx context.Context, id int64, commit string) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE projects SET git_root_commit = ? WHERE id = ?", commit, id)
+		if err != nil {
+			return fmt.Errorf("failed to save git root commit: %v", err)
+		}
+
+		return expectAffected(result, id)
+	})
+}
+
+// loadProjectDetails loads the tags and custom fields of a single project.
+func loadProjectDetails(ctx context.Context, q dbtx, project *models.Project) error {
+	var err error
+	project.Tags, err = getProjectTags(ctx, q, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get project tags: %v", err)
+	}
+
+	project.CustomFields, err = getProjectFields(ctx, q, project.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project: %v", err)
+		return fmt.Errorf("failed to get project fields: %v", err)
 	}
 
-	project.Tags = strings.Split(tagsStr, ",")
+	return nil
+}
+
+// nullTime stores the zero time as NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// nullPosition stores the pin position 0 of unpinned projects as NULL.
+func nullPosition(position int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(position), Valid: position > 0}
+}
+
+// Delete moves a project to the trash. It keeps all its data and can be
+// undone with Restore until the project is purged.
+func (r *SQLiteProjectRepository) Delete(ctx context.Context, id int64) error {
+	query := `
+		UPDATE projects
+		SET deleted_at = ?, pinned_position = NULL
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	return r.inTx(ctx, func(tx dbtx) error {
+		result, err := tx.ExecContext(ctx, query, time.Now(), id)
+		if err != nil {
+			return fmt.Errorf("failed to delete project: %v", err)
+		}
+
+		err = expectAffected(result, id)
+		if err != nil {
+			return err
+		}
+
+		ids, err := pinnedIDs(ctx, tx, 0)
+		if err != nil {
+			return err
+		}
+		return setPinOrder(ctx, tx, ids)
+	})
+}
+
+func (r *SQLiteProjectRepository) Restore(ctx context.Context, id int64) error {
+	query := `
+		UPDATE projects
+		SET deleted_at = NULL
+		WHERE id = ? AND deleted_at IS NOT NULL
+	`
+
+	return r.inTx(ctx, func(tx dbtx) error {
+		result, err := tx.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("failed to restore project: %v", err)
+		}
+
+		return expectAffected(result, id)
+	})
+}
+
+// Purge removes a project permanently, together with its tags, launches
+// and search index entry.
+func (r *SQLiteProjectRepository) Purge(ctx context.Context, id int64) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM projects WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to purge project: %v", err)
+		}
+
+		err = expectAffected(result, id)
+		if err != nil {
+			return err
+		}
+
+		err = unindexProject(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to remove project from index: %v", err)
+		}
+
+		err = deleteUnusedTags(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to clean up tags: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// PurgeTrash permanently removes every project that was moved to the trash
+// before the given time and returns their IDs.
+func (r *SQLiteProjectRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time) ([]int64, error) {
+	var purged []int64
+
+	err := r.inTx(ctx, func(tx dbtx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id FROM projects
+			WHERE deleted_at IS NOT NULL AND julianday(deleted_at) < julianday(?)
+		`, deletedBefore)
+		if err != nil {
+			return fmt.Errorf("failed to query trash: %v", err)
+		}
+
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan trashed project: %v", err)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error reading trash: %v", err)
+		}
+
+		for _, id := range ids {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM projects WHERE id = ?", id); err != nil {
+				return fmt.Errorf("failed to purge project %d: %v", id, err)
+			}
+			if err := unindexProject(ctx, tx, id); err != nil {
+				return fmt.Errorf("failed to remove project from index: %v", err)
+			}
+		}
+
+		if err := deleteUnusedTags(ctx, tx); err != nil {
+			return fmt.Errorf("failed to clean up tags: %v", err)
+		}
+
+		purged = ids
+		return nil
+	})
+
+	return purged, err
+}
+
+// GetByID returns a project, including projects that are in the trash.
+func (r *SQLiteProjectRepository) GetByID(ctx context.Context, id int64) (*models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		WHERE p.id = ?
+	`
+
+	project, err := scanProject(r.conn().QueryRowContext(ctx, query, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %d: %w", id, mapError(err))
+	}
+
+	err = loadProjectDetails(ctx, r.conn(), &project)
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// GetByPath returns the project registered for path, including trashed ones.
+func (r *SQLiteProjectRepository) GetByPath(ctx context.Context, path string) (*models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		WHERE p.path = ?
+	`
+
+	project, err := scanProject(r.conn().QueryRowContext(ctx, query, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", path, mapError(err))
+	}
+
+	err = loadProjectDetails(ctx, r.conn(), &project)
+	if err != nil {
+		return nil, err
+	}
 
 	return &project, nil
 }
 
-func (r *SQLiteProjectRepository) ListAll() ([]models.Project, error) {
+func (r *SQLiteProjectRepository) List(ctx context.Context, opts models.ListOptions) ([]models.Project, error) {
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = models.SortByName
+	}
+	column, ok := sortColumns[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field %q", sortBy)
+	}
+
+	direction := "ASC"
+	if opts.Descending {
+		direction = "DESC"
+	}
+
+	condition, args := listCondition(opts)
+	condition += " ORDER BY "
+	if opts.PinnedFirst {
+		condition += "p.pinned_position IS NULL, p.pinned_position, "
+	}
+	condition += fmt.Sprintf("%s %s, p.id %s", column, direction, direction)
+
+	// SQLite only accepts OFFSET after a LIMIT, where -1 means no limit.
+	if opts.Limit > 0 || opts.Offset > 0 {
+		limit := opts.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+		condition += " LIMIT ? OFFSET ?"
+		args = append(args, limit, max(opts.Offset, 0))
+	}
+
+	return r.listProjects(ctx, condition, args...)
+}
+
+func (r *SQLiteProjectRepository) Count(ctx context.Context, opts models.ListOptions) (int, error) {
+	condition, args := listCondition(opts)
+
+	var count int
+	err := r.conn().QueryRowContext(ctx, "SELECT COUNT(*) FROM projects p WHERE "+condition, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count projects: %v", err)
+	}
+
+	return count, nil
+}
+
+// sortColumns maps sort fields to the columns of projects p they order by.
+var sortColumns = map[models.SortField]string{
+	models.SortByName:       "p.name COLLATE NOCASE",
+	models.SortByLastOpened: "p.last_opened",
+	models.SortByCreated:    "p.created_at",
+	models.SortByPath:       "p.path",
+}
+
+// listCondition translates the filters of opts into a condition for listProjects.
+func listCondition(opts models.ListOptions) (string, []any) {
+	conditions := []string{"1"}
+	var args []any
+
+	if !opts.IncludeTrashed {
+		conditions = append(conditions, "p.deleted_at IS NULL")
+	}
+
+	if len(opts.Statuses) > 0 {
+		placeholders := make([]string, len(opts.Statuses))
+		for i, status := range opts.Statuses {
+			placeholders[i] = "?"
+			args = append(args, status)
+		}
+		conditions = append(conditions, "p.status IN ("+strings.Join(placeholders, ", ")+")")
+	} else if !opts.IncludeArchived {
+		conditions = append(conditions, "p.status != ?")
+		args = append(args, models.StatusArchived)
+	}
+
+	for _, tag := range normalizeTags(opts.Tags) {
+		conditions = append(conditions, `p.id IN (
+			SELECT pt.project_id
+			FROM project_tags pt
+			JOIN tags t ON t.id = pt.tag_id
+			WHERE t.name = ?
+		)`)
+		args = append(args, tag)
+	}
+
+	for _, group := range opts.Groups {
+		condition, groupArgs := groupCondition(group)
+		conditions = append(conditions, condition)
+		args = append(args, groupArgs...)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (r *SQLiteProjectRepository) ListTrash(ctx context.Context) ([]models.Project, error) {
+	return r.listProjects(ctx, "p.deleted_at IS NOT NULL")
+}
+
+// listProjects returns the projects matching an SQL condition on the
+// projects table aliased as p, with their tags.
+func (r *SQLiteProjectRepository) listProjects(ctx context.Context, condition string, args ...any) ([]models.Project, error) {
 	query := `
-        SELECT id, name, path, description, readme_path, 
-               last_opened, tags, icon 
-        FROM projects
-    `
+		SELECT ` + projectColumns + `
+		FROM projects p
+		WHERE ` + condition
 
-	rows, err := r.db.Query(query)
+	rows, err := r.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query projects: %v", err)
 	}
@@ -138,27 +770,11 @@ func (r *SQLiteProjectRepository) ListAll() ([]models.Project, error) {
 	var projects []models.Project
 
 	for rows.Next() {
-		var project models.Project
-		var tagsStr string
-
-		err := rows.Scan(
-			&project.ID,
-			&project.Name,
-			&project.Path,
-			&project.Description,
-			&project.ReadmePath,
-			&project.LastOpened,
-			&tagsStr,
-			&project.Icon,
-		)
+		project, err := scanProject(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan project: %v", err)
 		}
 
-		if tagsStr != "" {
-			project.Tags = strings.Split(tagsStr, ",")
-		}
-
 		projects = append(projects, project)
 	}
 
@@ -166,13 +782,15 @@ func (r *SQLiteProjectRepository) ListAll() ([]models.Project, error) {
 		return nil, fmt.Errorf("error reading projects: %v", err)
 	}
 
-	return projects, nil
-}
+	err = attachTags(ctx, r.conn(), projects)
+	if err != nil {
+		return nil, err
+	}
 
-type SQLiteProjectRepository struct {
-	db *sql.DB
-}
+	err = attachFields(ctx, r.conn(), projects)
+	if err != nil {
+		return nil, err
+	}
 
-func NewProjectRepository(storage *SQLiteStorage) ProjectRepository {
-	return &SQLiteProjectRepository{db: storage.db}
+	return projects, nil
 }
@@ -0,0 +1,343 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/query"
+)
+
+// maxIndexedReadmeSize caps how much of a README is copied into the search index.
+const maxIndexedReadmeSize = 256 * 1024
+
+// Markers wrapped around matched terms by snippet(); they are control
+// characters so they never collide with text from the indexed columns.
+const (
+	highlightStart = "\x02"
+	highlightEnd   = "\x03"
+)
+
+// checkFTS5 verifies that the linked SQLite library was compiled with FTS5.
+func checkFTS5(ctx context.Context, q dbtx) error {
+	var enabled bool
+	err := q.QueryRowContext(ctx, "SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled)
+	if err != nil {
+		return fmt.Errorf("failed to check SQLite compile options: %v", err)
+	}
+	if !enabled {
+		return fmt.Errorf("SQLite was built without FTS5 support, rebuild with -tags sqlite_fts5")
+	}
+	return nil
+}
+
+// readIndexableReadme returns the text of a README for indexing. Missing or
+// unreadable files are indexed as empty rather than failing the write.
+func readIndexableReadme(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	buf := make([]byte, maxIndexedReadmeSize)
+	n, _ := file.Read(buf)
+	content := buf[:n]
+	if !utf8.Valid(content) {
+		content = []byte(strings.ToValidUTF8(string(content), ""))
+	}
+
+	return string(content)
+}
+
+// indexProject writes the current state of a project into the search index.
+func indexProject(ctx context.Context, q dbtx, project *models.Project) error {
+	_, err := q.ExecContext(ctx, "DELETE FROM projects_fts WHERE rowid = ?", project.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.ExecContext(ctx,
+		"INSERT INTO projects_fts (rowid, name, description, path, tags, readme) VALUES (?, ?, ?, ?, ?, ?)",
+		project.ID,
+		project.Name,
+		project.Description,
+		project.Path,
+		strings.Join(normalizeTags(project.Tags), " "),
+		readIndexableReadme(project.ReadmePath),
+	)
+	return err
+}
+
+// unindexProject removes a project from the search index.
+func unindexProject(ctx context.Context, q dbtx, projectID int64) error {
+	_, err := q.ExecContext(ctx, "DELETE FROM projects_fts WHERE rowid = ?", projectID)
+	return err
+}
+
+// reindexTags refreshes the indexed tags of the given projects after a
+// tag was renamed, merged or deleted.
+func reindexTags(ctx context.Context, q dbtx, projectIDs []int64) error {
+	for _, id := range projectIDs {
+		tags, err := getProjectTags(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.ExecContext(ctx, "UPDATE projects_fts SET tags = ? WHERE rowid = ?", strings.Join(tags, " "), id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectsWithTag returns the IDs of all projects carrying the given tag.
+func projectsWithTag(ctx context.Context, q dbtx, tagID int64) ([]int64, error) {
+	rows, err := q.QueryContext(ctx, "SELECT project_id FROM project_tags WHERE tag_id = ?", tagID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// buildMatchExpression turns a free text term into an FTS5 query. Words must
+// match as prefixes, phrases must match exactly. Quoting every word keeps
+// user input from being interpreted as FTS5 operators.
+func buildMatchExpression(term query.Term) string {
+	if term.Phrase {
+		return `"` + strings.ReplaceAll(term.Value, `"`, `""`) + `"`
+	}
+
+	var words []string
+	for _, word := range strings.Fields(term.Value) {
+		word = strings.ReplaceAll(word, `"`, `""`)
+		words = append(words, `"`+word+`"*`)
+	}
+	return strings.Join(words, " ")
+}
+
+// escapeLike escapes the LIKE wildcards in a value; use with ESCAPE '\'.
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// compileTerm translates a single query term into an SQL condition on the
+// projects table aliased as p.
+func compileTerm(term query.Term, now time.Time) (string, []any, error) {
+	if term.Custom {
+		return compileCustomField(term)
+	}
+
+	switch term.Field {
+	case "":
+		return "p.id IN (SELECT rowid FROM projects_fts WHERE projects_fts MATCH ?)",
+			[]any{buildMatchExpression(term)}, nil
+
+	case query.FieldName:
+		return `p.name LIKE ? ESCAPE '\'`, []any{"%" + escapeLike(term.Value) + "%"}, nil
+
+	case query.FieldPath:
+		path := expandHome(term.Value)
+		if filepath.IsAbs(path) {
+			return `p.path LIKE ? ESCAPE '\'`, []any{escapeLike(path) + "%"}, nil
+		}
+		return `p.path LIKE ? ESCAPE '\'`, []any{"%" + escapeLike(path) + "%"}, nil
+
+	case query.FieldTag:
+		return `EXISTS (
+			SELECT 1 FROM project_tags pt
+			JOIN tags t ON t.id = pt.tag_id
+			WHERE pt.project_id = p.id AND t.name = ?
+		)`, []any{term.Value}, nil
+
+	case query.FieldStatus:
+		return "p.status = ?", []any{term.Value}, nil
+
+	case query.FieldGroup:
+		condition, args := groupCondition(term.Value)
+		return condition, args, nil
+
+	case query.FieldOpened:
+		return compileOpened(term, now)
+	}
+
+	return "", nil, fmt.Errorf("unsupported search field: %s", term.Field)
+}
+
+// compileOpened translates an opened: term. Projects that were never opened
+// have a NULL or zero last_opened and count as opened infinitely long ago.
+func compileOpened(term query.Term, now time.Time) (string, []any, error) {
+	neverOpened := "(p.last_opened IS NULL OR julianday(p.last_opened) <= julianday('0001-01-02'))"
+	if term.Never {
+		return neverOpened, nil, nil
+	}
+
+	if !term.Date.IsZero() {
+		if term.Op == query.OpLess {
+			return "(julianday(p.last_opened) < julianday(?) AND NOT " + neverOpened + ")", []any{term.Date}, nil
+		}
+		return "julianday(p.last_opened) >= julianday(?)", []any{term.Date.AddDate(0, 0, 1)}, nil
+	}
+
+	threshold := now.Add(-term.Age)
+	if term.Op == query.OpLess {
+		return "julianday(p.last_opened) >= julianday(?)", []any{threshold}, nil
+	}
+	return "(" + neverOpened + " OR julianday(p.last_opened) < julianday(?))", []any{threshold}, nil
+}
+
+// parseSnippet splits a snippet produced with the highlight markers into fragments.
+func parseSnippet(snippet string) []models.SnippetFragment {
+	var fragments []models.SnippetFragment
+
+	for snippet != "" {
+		start := strings.Index(snippet, highlightStart)
+		if start < 0 {
+			fragments = append(fragments, models.SnippetFragment{Text: snippet})
+			break
+		}
+		if start > 0 {
+			fragments = append(fragments, models.SnippetFragment{Text: snippet[:start]})
+		}
+		snippet = snippet[start+len(highlightStart):]
+
+		end := strings.Index(snippet, highlightEnd)
+		if end < 0 {
+			end = len(snippet)
+		}
+		fragments = append(fragments, models.SnippetFragment{Text: snippet[:end], Highlighted: true})
+		snippet = strings.TrimPrefix(snippet[end:], highlightEnd)
+	}
+
+	return fragments
+}
+
+func (r *SQLiteProjectRepository) Search(ctx context.Context, q *query.Query) ([]models.SearchResult, error) {
+	if q.IsEmpty() {
+		return nil, nil
+	}
+
+	var conditions []string
+	var conditionArgs []any
+	var rankTerms []string
+	now := time.Now()
+
+	for _, group := range q.Groups {
+		var alternatives []string
+		for _, term := range group {
+			condition, args, err := compileTerm(term, now)
+			if err != nil {
+				return nil, err
+			}
+			if term.Negated {
+				condition = "NOT COALESCE((" + condition + "), 0)"
+			} else if term.Field == "" {
+				rankTerms = append(rankTerms, "("+buildMatchExpression(term)+")")
+			}
+			alternatives = append(alternatives, condition)
+			conditionArgs = append(conditionArgs, args...)
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	// Free text terms rank the results with bm25 and provide the snippet;
+	// queries made only of field qualifiers are ordered by name.
+	// Column weights for bm25: name, description, path, tags, readme.
+	rankSelect := "0.0, ''"
+	rankJoin := ""
+	var args []any
+	if len(rankTerms) > 0 {
+		rankSelect = "COALESCE(m.rank, 0.0), COALESCE(m.snippet, '')"
+		rankJoin = `
+		LEFT JOIN (
+			SELECT rowid,
+			       bm25(projects_fts, 10.0, 4.0, 2.0, 6.0, 1.0) AS rank,
+			       snippet(projects_fts, -1, ?, ?, '…', 12) AS snippet
+			FROM projects_fts
+			WHERE projects_fts MATCH ?
+		) m ON m.rowid = p.id`
+		args = append(args, highlightStart, highlightEnd, strings.Join(rankTerms, " OR "))
+	}
+	args = append(args, conditionArgs...)
+
+	query := `
+		SELECT ` + projectColumns + `, ` + rankSelect + `
+		FROM projects p` + rankJoin + `
+		WHERE p.deleted_at IS NULL AND ` + strings.Join(conditions, " AND ")
+	if rankJoin != "" {
+		query += `
+		ORDER BY m.rank IS NULL, m.rank, p.name COLLATE NOCASE`
+	} else {
+		query += `
+		ORDER BY p.name COLLATE NOCASE`
+	}
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search projects: %v", err)
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		var result models.SearchResult
+		var snippet string
+		var err error
+
+		result.Project, err = scanProject(rows, &result.Rank, &snippet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %v", err)
+		}
+
+		result.Snippet = parseSnippet(snippet)
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading search results: %v", err)
+	}
+
+	for i := range results {
+		err = loadProjectDetails(ctx, r.conn(), &results[i].Project)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// recordStatus stores a status transition in the status history.
+func recordStatus(ctx context.Context, q dbtx, projectID int64, from, to models.Status, changedAt time.Time) error {
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO project_status_history (project_id, from_status, to_status, changed_at)
+		VALUES (?, ?, ?, ?)
+	`, projectID, from, to, changedAt)
+	return err
+}
+
+// SetStatus changes the status of a project and records the transition. It
+// does not check whether the transition is allowed.
+func (r *SQLiteProjectRepository) SetStatus(ctx context.Context, id int64, status models.Status, changedAt time.Time) error {
+	return r.inTx(ctx, func(tx dbtx) error {
+		var current models.Status
+		err := tx.QueryRowContext(ctx, "SELECT status FROM projects WHERE id = ?", id).Scan(&current)
+		if err != nil {
+			return fmt.Errorf("project %d: %w", id, mapError(err))
+		}
+		if current == status {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE projects SET status = ? WHERE id = ?", status, id)
+		if err != nil {
+			return fmt.Errorf("failed to change status: %v", err)
+		}
+
+		err = recordStatus(ctx, tx, id, current, status, changedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record status change: %v", err)
+		}
+		return nil
+	})
+}
+
+// ListStatusHistory returns the status transitions of a project, newest first.
+func (r *SQLiteProjectRepository) ListStatusHistory(ctx context.Context, projectID int64) ([]models.StatusChange, error) {
+	query := `
+		SELECT from_status, to_status, changed_at
+		FROM project_status_history
+		WHERE project_id = ?
+		ORDER BY changed_at DESC, id DESC
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %v", err)
+	}
+	defer rows.Close()
+
+	var changes []models.StatusChange
+	for rows.Next() {
+		change := models.StatusChange{ProjectID: projectID}
+		if err := rows.Scan(&change.From, &change.To, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status history: %v", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading status history: %v", err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,336 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// normalizeTags trims whitespace, drops empty entries and removes
+// case-insensitive duplicates while keeping the original order.
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+// ensureTag returns the ID of the tag with the given name, creating it if needed.
+func ensureTag(ctx context.Context, q dbtx, name string) (int64, error) {
+	_, err := q.ExecContext(ctx, "INSERT INTO tags (name) VALUES (?) ON CONFLICT(name) DO NOTHING", name)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = q.QueryRowContext(ctx, "SELECT id FROM tags WHERE name = ?", name).Scan(&id)
+	return id, err
+}
+
+// lookupTagID returns the ID of an existing tag, matching the name case-insensitively.
+func lookupTagID(ctx context.Context, q dbtx, name string) (int64, error) {
+	var id int64
+	err := q.QueryRowContext(ctx, "SELECT id FROM tags WHERE name = ?", strings.TrimSpace(name)).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("tag %s: %w", name, ErrNotFound)
+	}
+	return id, err
+}
+
+// setProjectTags replaces the tags of a project with the given list.
+func setProjectTags(ctx context.Context, q dbtx, projectID int64, tags []string) error {
+	_, err := q.ExecContext(ctx, "DELETE FROM project_tags WHERE project_id = ?", projectID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range normalizeTags(tags) {
+		tagID, err := ensureTag(ctx, q, tag)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.ExecContext(ctx,
+			"INSERT OR IGNORE INTO project_tags (project_id, tag_id) VALUES (?, ?)",
+			projectID,
+			tagID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getProjectTags returns the tag names of a single project ordered by name.
+func getProjectTags(ctx context.Context, q dbtx, projectID int64) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT t.name
+		FROM project_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.project_id = ?
+		ORDER BY t.name
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tags = append(tags, name)
+	}
+
+	return tags, rows.Err()
+}
+
+// attachTags loads the tags of all given projects with a single query.
+func attachTags(ctx context.Context, q dbtx, projects []models.Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT pt.project_id, t.name
+		FROM project_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		ORDER BY t.name
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query project tags: %v", err)
+	}
+	defer rows.Close()
+
+	tagsByProject := make(map[int64][]string)
+	for rows.Next() {
+		var projectID int64
+		var name string
+		if err := rows.Scan(&projectID, &name); err != nil {
+			return fmt.Errorf("failed to scan project tag: %v", err)
+		}
+		tagsByProject[projectID] = append(tagsByProject[projectID], name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading project tags: %v", err)
+	}
+
+	for i := range projects {
+		projects[i].Tags = tagsByProject[projects[i].ID]
+	}
+
+	return nil
+}
+
+// deleteUnusedTags removes tags that are no longer attached to any project.
+func deleteUnusedTags(ctx context.Context, q dbtx) error {
+	_, err := q.ExecContext(ctx, "DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM project_tags)")
+	return err
+}
+
+func (r *SQLiteProjectRepository) ListTags(ctx context.Context) ([]models.Tag, error) {
+	query := `
+		SELECT t.name, COUNT(p.id)
+		FROM tags t
+		LEFT JOIN project_tags pt ON pt.tag_id = t.id
+		LEFT JOIN projects p ON p.id = pt.project_id AND p.deleted_at IS NULL
+		GROUP BY t.id
+		ORDER BY t.name
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %v", err)
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %v", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading tags: %v", err)
+	}
+
+	return tags, nil
+}
+
+func (r *SQLiteProjectRepository) RenameTag(ctx context.Context, oldName, newName string) ([]int64, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("tag name cannot be empty")
+	}
+
+	var affected []int64
+	err := r.inTx(ctx, func(tx dbtx) error {
+		oldID, err := lookupTagID(ctx, tx, oldName)
+		if err != nil {
+			return err
+		}
+
+		affected, err = projectsWithTag(ctx, tx, oldID)
+		if err != nil {
+			return fmt.Errorf("failed to look up tagged projects: %v", err)
+		}
+
+		// Renaming onto another existing tag is the same as merging into it.
+		var existingID int64
+		err = tx.QueryRowContext(ctx, "SELECT id FROM tags WHERE name = ?", newName).Scan(&existingID)
+		switch {
+		case err == sql.ErrNoRows || existingID == oldID:
+			_, err = tx.ExecContext(ctx, "UPDATE tags SET name = ? WHERE id = ?", newName, oldID)
+			if err != nil {
+				return fmt.Errorf("failed to rename tag: %v", err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to look up tag: %v", err)
+		default:
+			err = mergeTag(ctx, tx, oldID, existingID)
+			if err != nil {
+				return fmt.Errorf("failed to merge tag: %v", err)
+			}
+		}
+
+		err = reindexTags(ctx, tx, affected)
+		if err != nil {
+			return fmt.Errorf("failed to update search index: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
+
+func (r *SQLiteProjectRepository) MergeTags(ctx context.Context, sources []string, target string) ([]int64, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return nil, fmt.Errorf("tag name cannot be empty")
+	}
+
+	var affected []int64
+	err := r.inTx(ctx, func(tx dbtx) error {
+		targetID, err := ensureTag(ctx, tx, target)
+		if err != nil {
+			return fmt.Errorf("failed to create tag: %v", err)
+		}
+
+		affected = nil
+		for _, source := range sources {
+			sourceID, err := lookupTagID(ctx, tx, source)
+			if err != nil {
+				return err
+			}
+			if sourceID == targetID {
+				continue
+			}
+
+			ids, err := projectsWithTag(ctx, tx, sourceID)
+			if err != nil {
+				return fmt.Errorf("failed to look up tagged projects: %v", err)
+			}
+			affected = append(affected, ids...)
+
+			err = mergeTag(ctx, tx, sourceID, targetID)
+			if err != nil {
+				return fmt.Errorf("failed to merge tag %s: %v", source, err)
+			}
+		}
+
+		err = reindexTags(ctx, tx, affected)
+		if err != nil {
+			return fmt.Errorf("failed to update search index: %v", err)
+		}
+
+		err = deleteUnusedTags(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to clean up tags: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
+
+// mergeTag moves every project from the source tag to the target tag and removes the source.
+func mergeTag(ctx context.Context, q dbtx, sourceID, targetID int64) error {
+	_, err := q.ExecContext(ctx, `
+		INSERT OR IGNORE INTO project_tags (project_id, tag_id)
+		SELECT project_id, ? FROM project_tags WHERE tag_id = ?
+	`, targetID, sourceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.ExecContext(ctx, "DELETE FROM project_tags WHERE tag_id = ?", sourceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.ExecContext(ctx, "DELETE FROM tags WHERE id = ?", sourceID)
+	return err
+}
+
+func (r *SQLiteProjectRepository) DeleteTag(ctx context.Context, name string) ([]int64, error) {
+	var affected []int64
+	err := r.inTx(ctx, func(tx dbtx) error {
+		id, err := lookupTagID(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		affected, err = projectsWithTag(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to look up tagged projects: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM project_tags WHERE tag_id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to detach tag: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM tags WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete tag: %v", err)
+		}
+
+		err = reindexTags(ctx, tx, affected)
+		if err != nil {
+			return fmt.Errorf("failed to update search index: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
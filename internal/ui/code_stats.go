@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+// maxLanguagesShown limits the languages listed in the details pane; the
+// rest are summed up on one line.
+const maxLanguagesShown = 6
+
+// analyzeInBackground counts the code of projects one after the other. The
+// results arrive as events, and the tags they add as project updates.
+func (ui *ProjectManagerUI) analyzeInBackground(ids ...int64) {
+	projectService := ui.projectService
+	go func() {
+		for _, id := range ids {
+			if _, err := projectService.AnalyzeProject(context.Background(), id); err != nil {
+				log.Printf("Failed to analyze project %d: %v", id, err)
+				if ui.isSelected(id) {
+					ui.codeDetails.SetText(fmt.Sprintf("Analysis failed: %v", err))
+				}
+			}
+		}
+	}()
+}
+
+// updateCodeStats shows a new analysis if it is of the selected project
+func (ui *ProjectManagerUI) updateCodeStats(event service.CodeStatsChanged) {
+	if ui.isSelected(event.ID) {
+		ui.showCodeStats(event.Stats)
+	}
+}
+
+// updateCodeDetails fills the code section of the details pane with the
+// stored analysis of a project
+func (ui *ProjectManagerUI) updateCodeDetails(project models.Project) {
+	if project.ID == 0 {
+		ui.codeDetails.SetText("")
+		return
+	}
+
+	stats, err := ui.projectService.CodeStats(context.Background(), project.ID)
+	if err != nil {
+		ui.codeDetails.SetText("Error loading code statistics")
+		log.Printf("Error loading code statistics: %v", err)
+		return
+	}
+	ui.showCodeStats(stats)
+}
+
+func (ui *ProjectManagerUI) showCodeStats(stats *models.CodeStats) {
+	if stats == nil {
+		ui.codeDetails.SetText("Not analyzed yet")
+		return
+	}
+
+	total := stats.Total()
+	if total.Files == 0 {
+		ui.codeDetails.SetText(fmt.Sprintf("No source files found\nAnalyzed %s", stats.AnalyzedAt.Local().Format("Jan 2 15:04")))
+		return
+	}
+
+	var text strings.Builder
+	shown := stats.Languages
+	if len(shown) > maxLanguagesShown {
+		shown = shown[:maxLanguagesShown-1]
+	}
+	for _, lang := range shown {
+		fmt.Fprintf(&text, "%-12s %5d files %8d code %7d comments %7d blank\n", lang.Language, lang.Files, lang.Code, lang.Comments, lang.Blanks)
+	}
+	if len(shown) < len(stats.Languages) {
+		others := models.CodeStats{Languages: stats.Languages[len(shown):]}.Total()
+		fmt.Fprintf(&text, "%-12s %5d files %8d code %7d comments %7d blank\n", fmt.Sprintf("%d others", len(stats.Languages)-len(shown)), others.Files, others.Code, others.Comments, others.Blanks)
+	}
+	fmt.Fprintf(&text, "%-12s %5d files %8d code %7d comments %7d blank\n", total.Language, total.Files, total.Code, total.Comments, total.Blanks)
+	fmt.Fprintf(&text, "Analyzed %s", stats.AnalyzedAt.Local().Format("Jan 2 15:04"))
+	ui.codeDetails.SetText(text.String())
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// noneOption is the entry of an enum select that clears the field.
+const noneOption = "(none)"
+
+// customFieldInput is the details form row that edits one custom field.
+type customFieldInput struct {
+	field  models.CustomFieldDefinition
+	widget fyne.CanvasObject
+	get    func() string
+	set    func(value string)
+}
+
+// newCustomFieldInput creates the input widget matching the field's type.
+// Values are validated by the service when the project is saved.
+func newCustomFieldInput(field models.CustomFieldDefinition) customFieldInput {
+	if field.Type == models.CustomFieldEnum {
+		sel := widget.NewSelect(append([]string{noneOption}, field.Options...), nil)
+		return customFieldInput{
+			field:  field,
+			widget: sel,
+			get: func() string {
+				if sel.Selected == noneOption {
+					return ""
+				}
+				return sel.Selected
+			},
+			set: func(value string) {
+				if value == "" {
+					sel.SetSelected(noneOption)
+					return
+				}
+				sel.SetSelected(value)
+			},
+		}
+	}
+
+	entry := widget.NewEntry()
+	input := customFieldInput{
+		field:  field,
+		widget: entry,
+		get:    func() string { return entry.Text },
+		set:    entry.SetText,
+	}
+
+	switch field.Type {
+	case models.CustomFieldNumber:
+		entry.SetPlaceHolder("Number")
+	case models.CustomFieldDate:
+		entry.SetPlaceHolder("YYYY-MM-DD")
+	case models.CustomFieldURL:
+		entry.SetPlaceHolder("https://")
+		openBtn := widget.NewButtonWithIcon("", theme.ComputerIcon(), func() {
+			if link, err := url.Parse(entry.Text); err == nil && link.Scheme != "" {
+				fyne.CurrentApp().OpenURL(link)
+			}
+		})
+		input.widget = container.NewBorder(nil, nil, nil, openBtn, entry)
+	}
+
+	return input
+}
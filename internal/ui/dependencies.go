@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+// allScopes is the option of the scope filter showing every dependency.
+const allScopes = "All scopes"
+
+// dependencyColumns are the headers of the dependency table, and their widths.
+var dependencyColumns = []struct {
+	title string
+	width float32
+}{
+	{"Name", 280},
+	{"Version", 140},
+	{"Scope", 80},
+	{"Ecosystem", 90},
+	{"Manifest", 160},
+}
+
+// createDependenciesPane builds the Dependencies tab of the details pane: a
+// table of the dependencies of the selected project, filtered by scope.
+func (ui *ProjectManagerUI) createDependenciesPane() fyne.CanvasObject {
+	ui.dependencySummary = widget.NewLabel("")
+	ui.dependencySummary.Wrapping = fyne.TextWrapWord
+
+	scopes := []string{allScopes}
+	for _, scope := range models.DependencyScopes {
+		scopes = append(scopes, string(scope))
+	}
+	ui.dependencyScope = widget.NewSelect(scopes, func(string) {
+		ui.filterDependencies()
+	})
+	ui.dependencyScope.SetSelected(allScopes)
+
+	scanBtn := widget.NewButton("Scan Manifests", func() {
+		project, ok := ui.selectedProject()
+		if !ok {
+			dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
+			return
+		}
+		ui.dependencySummary.SetText("Scanning...")
+		ui.scanDependenciesInBackground(project.ID)
+	})
+
+	ui.dependencyTable = widget.NewTable(
+		func() (int, int) {
+			return len(ui.shownDependencies), len(dependencyColumns)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.TableCellID, cell fyne.CanvasObject) {
+			if id.Row >= len(ui.shownDependencies) {
+				return
+			}
+			dep := ui.shownDependencies[id.Row]
+			text := ""
+			switch id.Col {
+			case 0:
+				text = dep.Name
+			case 1:
+				text = dep.Version
+				if dep.Indirect {
+					text += " (indirect)"
+				}
+			case 2:
+				text = string(dep.Scope)
+			case 3:
+				text = dep.Ecosystem
+			case 4:
+				text = dep.Manifest
+			}
+			cell.(*widget.Label).SetText(text)
+		},
+	)
+	ui.dependencyTable.ShowHeaderRow = true
+	ui.dependencyTable.CreateHeader = func() fyne.CanvasObject {
+		label := widget.NewLabel("")
+		label.TextStyle = fyne.TextStyle{Bold: true}
+		return label
+	}
+	ui.dependencyTable.UpdateHeader = func(id widget.TableCellID, header fyne.CanvasObject) {
+		if id.Col >= 0 && id.Col < len(dependencyColumns) {
+			header.(*widget.Label).SetText(dependencyColumns[id.Col].title)
+		}
+	}
+	for col, column := range dependencyColumns {
+		ui.dependencyTable.SetColumnWidth(col, column.width)
+	}
+
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, nil, scanBtn, ui.dependencyScope),
+		ui.dependencySummary,
+	)
+	return container.NewBorder(top, nil, nil, nil, ui.dependencyTable)
+}
+
+// scanDependenciesInBackground scans the manifests of projects one after the
+// other. The results arrive as events.
+func (ui *ProjectManagerUI) scanDependenciesInBackground(ids ...int64) {
+	projectService := ui.projectService
+	go func() {
+		for _, id := range ids {
+			deps, err := projectService.ScanDependencies(context.Background(), id)
+			if err == nil {
+				continue
+			}
+			log.Printf("Failed to scan dependencies of project %d: %v", id, err)
+			if !ui.isSelected(id) {
+				continue
+			}
+			if deps == nil {
+				ui.dependencySummary.SetText(fmt.Sprintf("Scan failed: %v", err))
+			} else {
+				ui.dependencySummary.SetText(fmt.Sprintf("%s\nSome manifests could not be parsed: %v", dependencySummary(deps), err))
+			}
+		}
+	}()
+}
+
+// updateDependencies shows a new scan if it is of the selected project
+func (ui *ProjectManagerUI) updateDependencies(event service.DependenciesChanged) {
+	if ui.isSelected(event.ID) {
+		ui.showDependencies(event.Dependencies)
+	}
+}
+
+// updateDependencyDetails fills the Dependencies tab with the stored scan of
+// a project
+func (ui *ProjectManagerUI) updateDependencyDetails(project models.Project) {
+	if project.ID == 0 {
+		ui.dependencySummary.SetText("")
+		ui.showDependencyRows(nil)
+		return
+	}
+
+	deps, err := ui.projectService.Dependencies(context.Background(), project.ID)
+	if err != nil {
+		ui.dependencySummary.SetText("Error loading dependencies")
+		ui.showDependencyRows(nil)
+		log.Printf("Error loading dependencies: %v", err)
+		return
+	}
+	ui.showDependencies(deps)
+}
+
+func (ui *ProjectManagerUI) showDependencies(deps *models.ProjectDependencies) {
+	if deps == nil {
+		ui.dependencySummary.SetText("Not scanned yet")
+		ui.showDependencyRows(nil)
+		return
+	}
+
+	ui.dependencySummary.SetText(dependencySummary(deps))
+	ui.showDependencyRows(deps.Dependencies)
+}
+
+// showDependencyRows lists dependencies in the table, subject to the scope filter.
+func (ui *ProjectManagerUI) showDependencyRows(dependencies []models.Dependency) {
+	ui.dependencies = dependencies
+	ui.filterDependencies()
+}
+
+func (ui *ProjectManagerUI) filterDependencies() {
+	if ui.dependencyTable == nil {
+		return
+	}
+
+	ui.shownDependencies = ui.dependencies
+	if scope := ui.dependencyScope.Selected; scope != allScopes && scope != "" {
+		ui.shownDependencies = nil
+		for _, dep := range ui.dependencies {
+			if string(dep.Scope) == scope {
+				ui.shownDependencies = append(ui.shownDependencies, dep)
+			}
+		}
+	}
+	ui.dependencyTable.Refresh()
+}
+
+// dependencySummary counts the dependencies of a scan per scope, e.g.
+// "12 dependencies: 8 runtime, 3 dev, 1 test".
+func dependencySummary(deps *models.ProjectDependencies) string {
+	scanned := deps.ScannedAt.Local().Format("Jan 2 15:04")
+	if len(deps.Dependencies) == 0 {
+		return fmt.Sprintf("No dependencies found\nScanned %s", scanned)
+	}
+
+	counts := make(map[models.DependencyScope]int)
+	for _, dep := range deps.Dependencies {
+		counts[dep.Scope]++
+	}
+	summary := fmt.Sprintf("%d dependencies:", len(deps.Dependencies))
+	for i, scope := range models.DependencyScopes {
+		if i > 0 {
+			summary += ","
+		}
+		summary += fmt.Sprintf(" %d %s", counts[scope], scope)
+	}
+	return fmt.Sprintf("%s\nScanned %s", summary, scanned)
+}
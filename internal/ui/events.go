@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+// reloadDelay gathers the reloads asked for by a burst of events, such as
+// those of an import, into a single one.
+const reloadDelay = 100 * time.Millisecond
+
+// handleEvent applies a change published by the project service to the
+// project list, the details pane and the tray menu. It runs on whatever
+// goroutine published the event, such as a background refresh, so the list
+// state it changes is guarded by listMu.
+func (ui *ProjectManagerUI) handleEvent(event service.Event) {
+	ui.updatePinned(event)
+
+	switch e := event.(type) {
+	case service.ProjectCreated:
+		// Where a new project belongs depends on the sorting and paging
+		// done by the database.
+		ui.reloadSoon()
+	case service.ProjectUpdated:
+		ui.applyUpdate(e.Project, true)
+	case service.ProjectOpened:
+		// Leave the details pane alone, it may hold unsaved edits.
+		ui.applyUpdate(e.Project, false)
+	case service.ProjectDeleted:
+		ui.removeListed(e.ID)
+	case service.GitStatusChanged:
+		ui.updateGitStatus(e)
+	case service.CodeStatsChanged:
+		ui.updateCodeStats(e)
+	case service.DependenciesChanged:
+		ui.updateDependencies(e)
+	}
+}
+
+// applyUpdate replaces a listed project with its new version. If the change
+// moves it within the page or off it, the page is reloaded instead.
+func (ui *ProjectManagerUI) applyUpdate(project models.Project, refreshDetails bool) {
+	ui.listMu.Lock()
+	index := ui.indexLocked(project.ID)
+	if index < 0 {
+		ui.listMu.Unlock()
+		return
+	}
+
+	listed := ui.currentProjects[index]
+	pinMoved := ui.listOptions.PinnedFirst && listed.PinnedPosition != project.PinnedPosition
+	reload := ui.browsingLocked() && (pinMoved || !matchesStatusFilter(ui.listOptions, project.Status))
+	if !reload {
+		ui.currentProjects[index] = project
+	}
+	selected := index == ui.selectedProjectIndex
+	ui.listMu.Unlock()
+
+	if reload {
+		ui.reloadSoon()
+		return
+	}
+	ui.projectList.RefreshItem(index)
+	if refreshDetails && selected {
+		ui.updateProjectDetails(project)
+	}
+}
+
+// matchesStatusFilter reports whether the status filter of the list lets
+// projects with the given status through.
+func matchesStatusFilter(opts models.ListOptions, status models.Status) bool {
+	if len(opts.Statuses) > 0 {
+		return slices.Contains(opts.Statuses, status)
+	}
+	return opts.IncludeArchived || status != models.StatusArchived
+}
+
+// removeListed takes a deleted project off the list. The details pane is
+// only cleared if it showed that project, so that edits of another one
+// are kept.
+func (ui *ProjectManagerUI) removeListed(id int64) {
+	ui.listMu.Lock()
+	index := ui.indexLocked(id)
+	if index < 0 {
+		ui.listMu.Unlock()
+		return
+	}
+
+	ui.currentProjects = slices.Delete(ui.currentProjects, index, index+1)
+	browsing := ui.browsingLocked()
+	if browsing {
+		ui.listedTotal--
+	}
+	shown, total := len(ui.currentProjects), ui.listedTotal
+	wasSelected := index == ui.selectedProjectIndex
+	movedUp := index < ui.selectedProjectIndex
+	if wasSelected {
+		ui.selectedProjectIndex = -1
+	} else if movedUp {
+		ui.selectedProjectIndex--
+	}
+	selected := ui.selectedProjectIndex
+	ui.listMu.Unlock()
+
+	if browsing {
+		ui.updatePager(shown, total)
+	}
+	if wasSelected {
+		ui.projectList.UnselectAll()
+		ui.updateProjectDetails(models.Project{})
+	} else if movedUp {
+		// The selected project moved up a row. selectIndex sees that it
+		// is selected already and leaves the details pane alone.
+		ui.projectList.Select(selected)
+	}
+	ui.projectList.Refresh()
+}
+
+// reloadSoon reloads the listed page shortly, keeping the selected project
+// selected. Search results are left as they are.
+func (ui *ProjectManagerUI) reloadSoon() {
+	if !ui.isBrowsing() {
+		return
+	}
+
+	ui.reloadMu.Lock()
+	defer ui.reloadMu.Unlock()
+	if ui.reloadPending {
+		return
+	}
+	ui.reloadPending = true
+
+	time.AfterFunc(reloadDelay, func() {
+		ui.reloadMu.Lock()
+		ui.reloadPending = false
+		ui.reloadMu.Unlock()
+
+		if !ui.isBrowsing() {
+			return
+		}
+
+		selected, ok := ui.selectedProject()
+		ui.loadProjects()
+		if ok {
+			ui.selectProject(selected.ID)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+// loadGitStatuses reads the cached git status of all projects. Events keep
+// them up to date after that.
+func (ui *ProjectManagerUI) loadGitStatuses() {
+	statuses, err := ui.projectService.GitStatuses(context.Background())
+	if err != nil {
+		log.Printf("Error loading git status: %v", err)
+		statuses = make(map[int64]models.GitStatus)
+	}
+
+	ui.gitMu.Lock()
+	ui.gitStatuses = statuses
+	ui.gitMu.Unlock()
+}
+
+func (ui *ProjectManagerUI) gitStatus(id int64) (models.GitStatus, bool) {
+	ui.gitMu.Lock()
+	defer ui.gitMu.Unlock()
+	status, ok := ui.gitStatuses[id]
+	return status, ok
+}
+
+// updateGitStatus applies a new git status to the list row and, if the
+// project is selected, the details pane
+func (ui *ProjectManagerUI) updateGitStatus(event service.GitStatusChanged) {
+	ui.gitMu.Lock()
+	if event.Status == nil {
+		delete(ui.gitStatuses, event.ID)
+	} else {
+		ui.gitStatuses[event.ID] = *event.Status
+	}
+	ui.gitMu.Unlock()
+
+	index := ui.listedIndex(event.ID)
+	if index < 0 {
+		return
+	}
+	ui.projectList.RefreshItem(index)
+	if project, ok := ui.selectedProject(); ok && project.ID == event.ID {
+		ui.updateGitDetails(project)
+	}
+}
+
+// refreshGitStatus inspects the repository of a project in the background;
+// the result arrives as an event.
+func (ui *ProjectManagerUI) refreshGitStatus(id int64) {
+	projectService := ui.projectService
+	go func() {
+		if _, err := projectService.RefreshGitStatus(context.Background(), id); err != nil {
+			log.Printf("Failed to refresh git status: %v", err)
+		}
+	}()
+}
+
+// setGitSummary shows the branch and the state of the working tree of a
+// project in its list row, e.g. "main ●3 ↑1 ↓2".
+func (ui *ProjectManagerUI) setGitSummary(label *widget.Label, id int64) {
+	status, ok := ui.gitStatus(id)
+	if !ok {
+		label.Hide()
+		return
+	}
+
+	parts := []string{"⎇ " + gitBranchName(status)}
+	if changes := status.Staged + status.Modified + status.Untracked + status.Conflicted; changes > 0 {
+		parts = append(parts, fmt.Sprintf("●%d", changes))
+	}
+	if status.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", status.Ahead))
+	}
+	if status.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", status.Behind))
+	}
+
+	label.Text = strings.Join(parts, " ")
+	label.Importance = widget.LowImportance
+	if status.Dirty() {
+		label.Importance = widget.WarningImportance
+	}
+	label.Show()
+	label.Refresh()
+}
+
+// updateGitDetails fills the git section of the details pane from the cache
+func (ui *ProjectManagerUI) updateGitDetails(project models.Project) {
+	if project.ID == 0 {
+		ui.gitDetails.SetText("")
+		return
+	}
+
+	status, ok := ui.gitStatus(project.ID)
+	if !ok {
+		ui.gitDetails.SetText("Not a git repository")
+		return
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Branch %s", gitBranchName(status))
+	if status.Upstream != "" {
+		fmt.Fprintf(&text, ", tracking %s: %d ahead, %d behind", status.Upstream, status.Ahead, status.Behind)
+	}
+	text.WriteString("\n")
+
+	if status.Dirty() {
+		fmt.Fprintf(&text, "%d staged, %d modified, %d untracked", status.Staged, status.Modified, status.Untracked)
+		if status.Conflicted > 0 {
+			fmt.Fprintf(&text, ", %d conflicted", status.Conflicted)
+		}
+		text.WriteString("\n")
+	} else {
+		text.WriteString("Working tree clean\n")
+	}
+
+	if status.LastCommitAt.IsZero() {
+		text.WriteString("No commits yet\n")
+	} else {
+		fmt.Fprintf(&text, "Last commit %s by %s\n", status.LastCommitAt.Local().Format("Jan 2 2006 15:04"), status.LastCommitAuthor)
+	}
+
+	names := make([]string, 0, len(status.Remotes))
+	for name := range status.Remotes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(&text, "%s: %s\n", name, status.Remotes[name])
+	}
+
+	fmt.Fprintf(&text, "Checked %s", status.CheckedAt.Local().Format("Jan 2 15:04"))
+	ui.gitDetails.SetText(text.String())
+}
+
+// gitBranchName returns the checked out branch, or says that HEAD is detached.
+func gitBranchName(status models.GitStatus) string {
+	if status.Branch == "" {
+		return "(detached)"
+	}
+	return status.Branch
+}
@@ -0,0 +1,323 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// allProjectsNode is the group tree node that lists projects regardless of their groups.
+const allProjectsNode = "all"
+
+// topLevelOption is the parent choice for groups that are not nested.
+const topLevelOption = "(top level)"
+
+// groupNode returns the tree node ID of a group.
+func groupNode(id int64) widget.TreeNodeID {
+	return strconv.FormatInt(id, 10)
+}
+
+// createGroupPane builds the group tree shown above the project list
+func (ui *ProjectManagerUI) createGroupPane() fyne.CanvasObject {
+	ui.groupTree = widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID {
+			if id == "" {
+				return append([]widget.TreeNodeID{allProjectsNode}, ui.groupChildren[""]...)
+			}
+			return ui.groupChildren[id]
+		},
+		func(id widget.TreeNodeID) bool {
+			return id == "" || len(ui.groupChildren[id]) > 0
+		},
+		func(branch bool) fyne.CanvasObject {
+			return widget.NewLabel("Group Template")
+		},
+		func(id widget.TreeNodeID, branch bool, item fyne.CanvasObject) {
+			label := item.(*widget.Label)
+			if id == allProjectsNode {
+				label.SetText("All Projects")
+				return
+			}
+			group := ui.groupsByNode[id]
+			label.SetText(fmt.Sprintf("%s (%d)", group.Name, group.ProjectCount))
+		},
+	)
+
+	ui.selectedGroup = allProjectsNode
+	ui.reloadGroups()
+	ui.groupTree.Select(allProjectsNode)
+	ui.groupTree.OnSelected = ui.selectGroup
+
+	toolbar := container.NewHBox(
+		widget.NewLabel("Groups"),
+		widget.NewButton("New", ui.showNewGroupDialog),
+		widget.NewButton("Rename", ui.showRenameGroupDialog),
+		widget.NewButton("Move", ui.showMoveGroupDialog),
+		widget.NewButton("Delete", ui.confirmDeleteGroup),
+	)
+
+	return container.NewBorder(toolbar, nil, nil, nil, ui.groupTree)
+}
+
+// selectGroup restricts the project list to the selected group and its subgroups
+func (ui *ProjectManagerUI) selectGroup(id widget.TreeNodeID) {
+	ui.selectedGroup = id
+	var groups []string
+	if group, ok := ui.groupsByNode[id]; ok {
+		// The leading separator makes the path absolute, so that a top-level
+		// group does not also select nested groups with the same name.
+		groups = []string{models.GroupPathSeparator + group.Path}
+	}
+	ui.changeListOptions(func(opts *models.ListOptions) {
+		opts.Groups = groups
+		opts.Offset = 0
+	})
+	ui.loadProjects()
+}
+
+// reloadGroups refreshes the group tree, falling back to all projects if the
+// selected group no longer exists
+func (ui *ProjectManagerUI) reloadGroups() {
+	groups, err := ui.projectService.ListGroups(context.Background())
+	if err != nil {
+		log.Printf("Error loading groups: %v", err)
+		return
+	}
+
+	ui.groups = groups
+	ui.groupsByNode = make(map[widget.TreeNodeID]models.Group, len(groups))
+	ui.groupChildren = make(map[widget.TreeNodeID][]widget.TreeNodeID)
+	for _, group := range groups {
+		node := groupNode(group.ID)
+		ui.groupsByNode[node] = group
+
+		parent := ""
+		if group.ParentID != 0 {
+			parent = groupNode(group.ParentID)
+		}
+		ui.groupChildren[parent] = append(ui.groupChildren[parent], node)
+	}
+
+	ui.groupTree.Refresh()
+
+	if _, ok := ui.groupsByNode[ui.selectedGroup]; !ok && ui.selectedGroup != allProjectsNode {
+		ui.groupTree.Select(allProjectsNode)
+	}
+}
+
+// selectedGroupItem returns the group selected in the tree, if any
+func (ui *ProjectManagerUI) selectedGroupItem() (models.Group, bool) {
+	group, ok := ui.groupsByNode[ui.selectedGroup]
+	return group, ok
+}
+
+// groupParentOptions returns the paths a group can be moved below, leaving
+// out the group itself and its subgroups when exclude is set
+func (ui *ProjectManagerUI) groupParentOptions(exclude *models.Group) []string {
+	options := []string{topLevelOption}
+	for _, group := range ui.groups {
+		if exclude != nil && (group.ID == exclude.ID || strings.HasPrefix(group.Path, exclude.Path+models.GroupPathSeparator)) {
+			continue
+		}
+		options = append(options, group.Path)
+	}
+	return options
+}
+
+// groupIDByPath returns the ID of the group with the given path, or 0 for the top level
+func (ui *ProjectManagerUI) groupIDByPath(path string) int64 {
+	for _, group := range ui.groups {
+		if group.Path == path {
+			return group.ID
+		}
+	}
+	return 0
+}
+
+// showNewGroupDialog creates a group, by default inside the selected one
+func (ui *ProjectManagerUI) showNewGroupDialog() {
+	nameEntry := widget.NewEntry()
+	parentSelect := widget.NewSelect(ui.groupParentOptions(nil), nil)
+	parentSelect.SetSelected(topLevelOption)
+	if group, ok := ui.selectedGroupItem(); ok {
+		parentSelect.SetSelected(group.Path)
+	}
+
+	items := []*widget.FormItem{
+		{Text: "Name", Widget: nameEntry},
+		{Text: "Inside", Widget: parentSelect},
+	}
+
+	dialog.ShowForm("New Group", "Create", "Cancel", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		group, err := ui.projectService.CreateGroup(context.Background(), nameEntry.Text, ui.groupIDByPath(parentSelect.Selected))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to create group: %v", err), ui.window)
+			return
+		}
+
+		ui.reloadGroups()
+		if group.ParentID != 0 {
+			ui.groupTree.OpenBranch(groupNode(group.ParentID))
+		}
+		ui.refreshProjectGroups()
+	}, ui.window)
+}
+
+// showRenameGroupDialog renames the selected group
+func (ui *ProjectManagerUI) showRenameGroupDialog() {
+	group, ok := ui.selectedGroupItem()
+	if !ok {
+		dialog.ShowError(fmt.Errorf("no group selected"), ui.window)
+		return
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(group.Name)
+
+	dialog.ShowForm("Rename Group", "Rename", "Cancel", []*widget.FormItem{{Text: "Name", Widget: nameEntry}}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		err := ui.projectService.RenameGroup(context.Background(), group.ID, nameEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to rename group: %v", err), ui.window)
+			return
+		}
+
+		ui.reloadGroups()
+		ui.selectGroup(ui.selectedGroup)
+	}, ui.window)
+}
+
+// showMoveGroupDialog gives the selected group a new parent
+func (ui *ProjectManagerUI) showMoveGroupDialog() {
+	group, ok := ui.selectedGroupItem()
+	if !ok {
+		dialog.ShowError(fmt.Errorf("no group selected"), ui.window)
+		return
+	}
+
+	parentSelect := widget.NewSelect(ui.groupParentOptions(&group), nil)
+	parentSelect.SetSelected(topLevelOption)
+	for _, parent := range ui.groups {
+		if parent.ID == group.ParentID {
+			parentSelect.SetSelected(parent.Path)
+		}
+	}
+
+	dialog.ShowForm(fmt.Sprintf("Move %s", group.Name), "Move", "Cancel", []*widget.FormItem{{Text: "Inside", Widget: parentSelect}}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		err := ui.projectService.MoveGroup(context.Background(), group.ID, ui.groupIDByPath(parentSelect.Selected))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to move group: %v", err), ui.window)
+			return
+		}
+
+		ui.reloadGroups()
+		ui.selectGroup(ui.selectedGroup)
+	}, ui.window)
+}
+
+// confirmDeleteGroup deletes the selected group and its subgroups, keeping their projects
+func (ui *ProjectManagerUI) confirmDeleteGroup() {
+	group, ok := ui.selectedGroupItem()
+	if !ok {
+		dialog.ShowError(fmt.Errorf("no group selected"), ui.window)
+		return
+	}
+
+	message := fmt.Sprintf("Delete group '%s'? Its projects are kept.", group.Path)
+	if len(ui.groupChildren[groupNode(group.ID)]) > 0 {
+		message = fmt.Sprintf("Delete group '%s' and its subgroups? Their projects are kept.", group.Path)
+	}
+
+	dialog.ShowConfirm("Delete Group", message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		err := ui.projectService.DeleteGroup(context.Background(), group.ID)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to delete group: %v", err), ui.window)
+			return
+		}
+
+		ui.reloadGroups()
+		ui.refreshProjectGroups()
+	}, ui.window)
+}
+
+// refreshProjectGroups updates the group memberships shown for the selected project
+func (ui *ProjectManagerUI) refreshProjectGroups() {
+	// An empty project clears the memberships if none is selected.
+	project, _ := ui.selectedProject()
+	ui.updateProjectGroups(project)
+}
+
+// updateProjectGroups lists the groups of a project in the details pane,
+// with controls to leave them and to join others
+func (ui *ProjectManagerUI) updateProjectGroups(project models.Project) {
+	ui.projectGroupsBox.RemoveAll()
+	if project.ID == 0 {
+		return
+	}
+
+	groups, err := ui.projectService.ProjectGroups(context.Background(), project.ID)
+	if err != nil {
+		ui.projectGroupsBox.Add(widget.NewLabel(fmt.Sprintf("Error loading groups: %v", err)))
+		return
+	}
+
+	member := make(map[int64]bool, len(groups))
+	for _, group := range groups {
+		member[group.ID] = true
+		groupID := group.ID
+		removeBtn := widget.NewButton("Remove", func() {
+			err := ui.projectService.RemoveProjectFromGroup(context.Background(), project.ID, groupID)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("failed to remove project from group: %v", err), ui.window)
+				return
+			}
+			ui.reloadGroups()
+		})
+		ui.projectGroupsBox.Add(container.NewBorder(nil, nil, nil, removeBtn, widget.NewLabel(group.Path)))
+	}
+
+	var options []string
+	for _, group := range ui.groups {
+		if !member[group.ID] {
+			options = append(options, group.Path)
+		}
+	}
+	if len(options) == 0 {
+		return
+	}
+
+	addSelect := widget.NewSelect(options, func(path string) {
+		err := ui.projectService.AddProjectToGroup(context.Background(), project.ID, ui.groupIDByPath(path))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to add project to group: %v", err), ui.window)
+			return
+		}
+		ui.reloadGroups()
+	})
+	addSelect.PlaceHolder = "Add to group..."
+	ui.projectGroupsBox.Add(addSelect)
+}
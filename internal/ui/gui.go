@@ -1,56 +1,143 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"image/color"
 	"io/ioutil"
 	"log"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/Agronomety/ProjectManager/internal/catalog"
+	"github.com/Agronomety/ProjectManager/internal/config"
 	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/query"
 	"github.com/Agronomety/ProjectManager/internal/service"
+	"github.com/Agronomety/ProjectManager/pkg/manifest"
 	"github.com/Agronomety/ProjectManager/pkg/utils"
 	"github.com/Agronomety/ProjectManager/pkg/vscode"
 )
 
+// pageSize is the number of projects listed per page.
+const pageSize = 100
+
+// sortLabels names the sort fields in the sort selector.
+var sortLabels = map[models.SortField]string{
+	models.SortByName:       "Name",
+	models.SortByLastOpened: "Last opened",
+	models.SortByCreated:    "Date added",
+	models.SortByPath:       "Path",
+}
+
 type ProjectManagerUI struct {
 	app                  fyne.App
 	window               fyne.Window
+	config               *config.Config
 	projectService       service.ProjectService
+	backupService        service.BackupService
+	healthService        service.HealthService
 	projectList          *widget.List
 	projectDetails       *widget.Form
 	descriptionEdit      *widget.Entry
+	tagsEdit             *widget.Entry
+	customFieldInputs    []customFieldInput
+	detailFieldErrors    map[string]*widget.Label
+	projectGroupsBox     *fyne.Container
+	statusBadge          *fyne.Container
+	statusSelect         *widget.Select
+	statusHistory        *widget.Label
+	historyBox           *fyne.Container
 	readmeViewer         *widget.Label
 	searchEntry          *widget.Entry
+	searchError          *widget.Label
 	readmeUploadBtn      *widget.Button
 	removeReadmeBtn      *widget.Button
+	pinBtn               *widget.Button
+	pinUpBtn             *widget.Button
+	pinDownBtn           *widget.Button
 	currentProjects      []models.Project
+	listOptions          models.ListOptions
+	pageLabel            *widget.Label
+	prevPageBtn          *widget.Button
+	nextPageBtn          *widget.Button
+	currentSnippets      map[int64][]models.SnippetFragment
+	currentHighlights    map[int64][]int
 	vsCodeLauncher       *vscode.Launcher
 	selectedProjectIndex int
+	groupTree            *widget.Tree
+	groups               []models.Group
+	groupsByNode         map[widget.TreeNodeID]models.Group
+	groupChildren        map[widget.TreeNodeID][]widget.TreeNodeID
+	selectedGroup        widget.TreeNodeID
+	listedTotal          int
+	pinned               []models.Project
+	profileSelect        *widget.Select
+	switchProfileFunc    ProfileSwitcher
+	unsubscribe          func()
+	reloadPending        bool
+	reloadMu             sync.Mutex
+	// listMu guards currentProjects, currentSnippets, currentHighlights,
+	// selectedProjectIndex, listedTotal, listOptions and pinned, which
+	// events change from other goroutines; see list_state.go.
+	listMu sync.Mutex
+	// missing holds the projects whose path no longer exists, by ID. It is
+	// updated by the background path check, hence the mutex.
+	missing   map[int64]models.MissingProject
+	missingMu sync.Mutex
+	// stopPathWatch stops the background path check of the catalog in use.
+	stopPathWatch context.CancelFunc
+	// gitStatuses holds the cached git status of the projects, by ID. It is
+	// updated by events from the background refresh, hence the mutex.
+	gitStatuses map[int64]models.GitStatus
+	gitMu       sync.Mutex
+	gitDetails  *widget.Label
+	codeDetails *widget.Label
+	// dependencies holds the dependencies of the selected project, and
+	// shownDependencies those of them that pass the scope filter.
+	dependencies      []models.Dependency
+	shownDependencies []models.Dependency
+	dependencyTable   *widget.Table
+	dependencyScope   *widget.Select
+	dependencySummary *widget.Label
 }
 
-// NewProjectManagerUI creates and initializes a new project manager UI
-func NewProjectManagerUI(projectService service.ProjectService) *ProjectManagerUI {
+// NewProjectManagerUI creates and initializes a new project manager UI.
+// switchProfile may be nil if the catalog cannot be changed while running.
+func NewProjectManagerUI(cfg *config.Config, projectService service.ProjectService, backupService service.BackupService, switchProfile ProfileSwitcher) *ProjectManagerUI {
 	a := app.New()
 	w := a.NewWindow("Project Manager")
 	w.Resize(fyne.NewSize(1200, 800))
 
 	ui := &ProjectManagerUI{
-		app:            a,
-		window:         w,
-		projectService: projectService,
-		vsCodeLauncher: vscode.NewLauncher(projectService),
+		app:               a,
+		window:            w,
+		config:            cfg,
+		projectService:    projectService,
+		backupService:     backupService,
+		healthService:     service.NewHealthService(projectService),
+		missing:           make(map[int64]models.MissingProject),
+		gitStatuses:       make(map[int64]models.GitStatus),
+		vsCodeLauncher:    vscode.NewLauncher(projectService, cfg.VSCodePath),
+		switchProfileFunc: switchProfile,
 	}
+	ui.unsubscribe = projectService.Subscribe(ui.handleEvent)
 
 	ui.createUI()
+	ui.updateTitle()
 	return ui
 }
 
@@ -71,48 +158,192 @@ func (ui *ProjectManagerUI) createUI() {
 	)
 
 	ui.projectList = widget.NewList(
-		func() int { return len(ui.currentProjects) },
+		ui.listedCount,
 		func() fyne.CanvasObject {
-			return widget.NewLabel("Project Template")
+			title := widget.NewRichTextWithText("Project Template")
+			snippet := widget.NewRichText()
+			snippet.Truncation = fyne.TextTruncateEllipsis
+			gitSummary := widget.NewLabel("")
+			header := container.NewBorder(nil, nil, nil, container.NewHBox(gitSummary, container.NewCenter(newStatusBadge())), title)
+			return container.NewVBox(header, snippet)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
-			label := item.(*widget.Label)
-			if id < len(ui.currentProjects) {
-				label.SetText(ui.currentProjects[id].Name)
+			row := item.(*fyne.Container)
+			header := row.Objects[0].(*fyne.Container)
+			title := header.Objects[0].(*widget.RichText)
+			trailing := header.Objects[1].(*fyne.Container)
+			gitSummary := trailing.Objects[0].(*widget.Label)
+			badge := trailing.Objects[1].(*fyne.Container).Objects[0].(*fyne.Container)
+			snippet := row.Objects[1].(*widget.RichText)
+			if project, highlights, fragments, ok := ui.listedRow(id); ok {
+				title.Segments = snippetSegments(highlightRunes(project.Name, highlights))
+				if project.PinnedPosition > 0 {
+					title.Segments = append([]widget.RichTextSegment{pinnedMarker()}, title.Segments...)
+				}
+				if ui.isMissing(project.ID) {
+					title.Segments = append([]widget.RichTextSegment{missingMarker()}, title.Segments...)
+				}
+				title.Refresh()
+				setStatusBadge(badge, project.Status)
+				ui.setGitSummary(gitSummary, project.ID)
+				snippet.Segments = snippetSegments(fragments)
+				snippet.Hidden = len(snippet.Segments) == 0
+				snippet.Refresh()
+				ui.projectList.SetItemHeight(id, row.MinSize().Height)
 			}
 		},
 	)
 
 	newProjectBtn := widget.NewButton("New Project", ui.showNewProjectDialog)
 	importProjectBtn := widget.NewButton("Import Projects", ui.showImportProjectsDialog)
+	usageBtn := widget.NewButton("Usage Statistics", ui.showUsageDialog)
+	trashBtn := widget.NewButton("Trash", ui.showTrashDialog)
+	missingBtn := widget.NewButton("Missing Projects", ui.showMissingProjectsDialog)
+	healthBtn := widget.NewButton("Health Check", ui.showHealthDialog)
 
 	buttonContainer := container.NewVBox(
+		ui.createProfileSelect(),
 		newProjectBtn,
 		importProjectBtn,
+		usageBtn,
+		trashBtn,
+		missingBtn,
+		healthBtn,
 	)
 
 	ui.searchEntry = widget.NewEntry()
-	ui.searchEntry.SetPlaceHolder("Search projects...")
+	ui.searchEntry.SetPlaceHolder("Search projects... (e.g. tag:go -opened:<30d)")
 	searchIcon := widget.NewButton("🔍", func() {
 		ui.performSearch(ui.searchEntry.Text)
 	})
 	searchBar := container.NewBorder(nil, nil, nil, searchIcon, ui.searchEntry)
 
-	ui.searchEntry.OnSubmitted = func(query string) {
-		ui.performSearch(query)
+	ui.changeListOptions(func(opts *models.ListOptions) {
+		*opts = models.ListOptions{SortBy: models.SortByName, Limit: pageSize, PinnedFirst: true}
+	})
+	sortOptions := make([]string, len(models.SortFields))
+	for i, field := range models.SortFields {
+		sortOptions[i] = sortLabels[field]
 	}
+	sortSelect := widget.NewSelect(sortOptions, nil)
+	sortSelect.SetSelected(sortLabels[models.SortByName])
+	sortSelect.OnChanged = func(selected string) {
+		ui.changeListOptions(func(opts *models.ListOptions) {
+			for field, label := range sortLabels {
+				if label == selected {
+					opts.SortBy = field
+				}
+			}
+			opts.Offset = 0
+		})
+		ui.loadProjects()
+	}
+	descendingCheck := widget.NewCheck("Descending", func(checked bool) {
+		ui.changeListOptions(func(opts *models.ListOptions) {
+			opts.Descending = checked
+			opts.Offset = 0
+		})
+		ui.loadProjects()
+	})
+
+	ui.pageLabel = widget.NewLabel("")
+	ui.prevPageBtn = widget.NewButton("◀", func() {
+		ui.changeListOptions(func(opts *models.ListOptions) {
+			opts.Offset = max(opts.Offset-pageSize, 0)
+		})
+		ui.loadProjects()
+	})
+	ui.nextPageBtn = widget.NewButton("▶", func() {
+		ui.changeListOptions(func(opts *models.ListOptions) {
+			opts.Offset += pageSize
+		})
+		ui.loadProjects()
+	})
+	sortBar := container.NewHBox(
+		widget.NewLabel("Sort by"), sortSelect, descendingCheck,
+		widget.NewLabel("Status"), ui.createStatusFilter(),
+	)
+	pageBar := container.NewHBox(ui.prevPageBtn, ui.pageLabel, ui.nextPageBtn)
+
+	ui.searchError = widget.NewLabel("")
+	ui.searchError.TextStyle = fyne.TextStyle{Monospace: true}
+	ui.searchError.Importance = widget.DangerImportance
+	ui.searchError.Hide()
+
+	// Plain text filters the list fuzzily while typing and Enter opens the
+	// best match; queries using the search syntax run on Enter.
+	ui.searchEntry.OnChanged = ui.onSearchChanged
+	ui.searchEntry.OnSubmitted = func(text string) {
+		if best, ok := ui.listedProject(0); ok && !isStructuredQuery(text) && strings.TrimSpace(text) != "" {
+			ui.openProject(best)
+			return
+		}
+		ui.performSearch(text)
+	}
+
+	groupSplit := container.NewVSplit(ui.createGroupPane(), ui.projectList)
+	groupSplit.Offset = 0.25
 
 	projectListContainer := container.NewBorder(
-		container.NewVBox(bannerContainer, buttonContainer, searchBar), // Top - banner and buttons
-		nil,            // Bottom
-		nil,            // Left
-		nil,            // Right
-		ui.projectList, // Center
+		container.NewVBox(bannerContainer, buttonContainer, searchBar, ui.searchError, sortBar), // Top - banner and buttons
+		pageBar,    // Bottom
+		nil,        // Left
+		nil,        // Right
+		groupSplit, // Center - group tree above the project list
 	)
 
 	ui.descriptionEdit = widget.NewMultiLineEntry()
 	ui.descriptionEdit.SetPlaceHolder("Enter project description...")
 
+	// One tag per line, since tag names may contain commas
+	ui.tagsEdit = widget.NewMultiLineEntry()
+	ui.tagsEdit.SetPlaceHolder("One tag per line")
+	ui.tagsEdit.SetMinRowsVisible(3)
+
+	ui.projectGroupsBox = container.NewVBox()
+
+	ui.statusBadge = newStatusBadge()
+	ui.statusSelect = widget.NewSelect(nil, nil)
+	ui.statusSelect.PlaceHolder = "Change to..."
+	ui.statusHistory = widget.NewLabel("")
+	ui.statusHistory.TextStyle = fyne.TextStyle{Italic: true}
+
+	saveBtn := widget.NewButton("Save Changes", ui.saveProjectDetails)
+
+	ui.pinBtn = widget.NewButton("Pin to Top", ui.togglePin)
+	ui.pinUpBtn = widget.NewButton("▲", func() { ui.movePin(-1) })
+	ui.pinDownBtn = widget.NewButton("▼", func() { ui.movePin(1) })
+
+	ui.detailFieldErrors = make(map[string]*widget.Label)
+	for _, field := range ui.projectService.CustomFields() {
+		ui.customFieldInputs = append(ui.customFieldInputs, newCustomFieldInput(field))
+		ui.detailFieldErrors[models.CustomFieldPrefix+field.Name] = newFieldErrorLabel()
+	}
+
+	ui.historyBox = container.NewVBox()
+
+	ui.codeDetails = widget.NewLabel("")
+	ui.codeDetails.TextStyle = fyne.TextStyle{Monospace: true}
+	analyzeBtn := widget.NewButton("Analyze", func() {
+		project, ok := ui.selectedProject()
+		if !ok {
+			dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
+			return
+		}
+		ui.codeDetails.SetText("Analyzing...")
+		ui.analyzeInBackground(project.ID)
+	})
+
+	ui.gitDetails = widget.NewLabel("")
+	refreshGitBtn := widget.NewButton("Refresh", func() {
+		project, ok := ui.selectedProject()
+		if !ok {
+			dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
+			return
+		}
+		ui.refreshGitStatus(project.ID)
+	})
+
 	ui.readmeViewer = widget.NewLabel("No README loaded")
 	ui.readmeViewer.Wrapping = fyne.TextWrapWord
 
@@ -123,50 +354,42 @@ func (ui *ProjectManagerUI) createUI() {
 	ui.removeReadmeBtn = widget.NewButton("Remove README", ui.removeReadmeFile)
 
 	openInVSCodeBtn := widget.NewButton("Open in VSCode", func() {
-		if ui.selectedProjectIndex < 0 || ui.selectedProjectIndex >= len(ui.currentProjects) {
+		project, ok := ui.selectedProject()
+		if !ok {
 			dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
 			return
 		}
-		project := ui.currentProjects[ui.selectedProjectIndex]
-		err := ui.vsCodeLauncher.OpenProject(&project)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("failed to open project in VSCode: %v", err), ui.window)
-			return
-		}
-		project.LastOpened = time.Now()
-		err = ui.projectService.UpdateProject(&project)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("failed to update last opened time: %v", err), ui.window)
+		ui.openProject(project)
+	})
+
+	relocateBtn := widget.NewButton("Relocate...", func() {
+		project, ok := ui.selectedProject()
+		if !ok {
+			dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
 			return
 		}
+		ui.browseRelocation(project)
 	})
 
 	removeProjectBtn := widget.NewButton("Remove Project", func() {
-		if ui.selectedProjectIndex < 0 || ui.selectedProjectIndex >= len(ui.currentProjects) {
+		project, ok := ui.selectedProject()
+		if !ok {
 			dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
 			return
 		}
 
-		project := ui.currentProjects[ui.selectedProjectIndex]
-
 		confirmDialog := dialog.NewConfirm(
 			"Confirm Removal",
-			fmt.Sprintf("Are you sure you want to remove project '%s'? This action cannot be undone.", project.Name),
+			fmt.Sprintf("Are you sure you want to remove project '%s'? It will be moved to the Trash.", project.Name),
 			func(confirmed bool) {
 				if !confirmed {
 					return
 				}
 
-				err := ui.projectService.DeleteProject(project.ID)
+				err := ui.projectService.DeleteProject(context.Background(), project.ID)
 				if err != nil {
 					dialog.ShowError(fmt.Errorf("failed to remove project: %v", err), ui.window)
-					return
 				}
-
-				ui.currentProjects = append(ui.currentProjects[:ui.selectedProjectIndex], ui.currentProjects[ui.selectedProjectIndex+1:]...)
-				ui.projectList.Refresh()
-				ui.selectedProjectIndex = -1              // Reset selection
-				ui.updateProjectDetails(models.Project{}) // Clear details
 			},
 			ui.window,
 		)
@@ -178,34 +401,79 @@ func (ui *ProjectManagerUI) createUI() {
 		Items: []*widget.FormItem{
 			{Text: "Project Name", Widget: widget.NewLabel("")},
 			{Text: "Description", Widget: ui.descriptionEdit},
-			{Widget: openInVSCodeBtn},
-			{Widget: removeProjectBtn},
-			{Widget: container.NewHBox(ui.readmeUploadBtn, ui.removeReadmeBtn)},
-			{Text: "README Viewer", Widget: readmeScrollContainer},
+			{Text: "Tags", Widget: ui.tagsEdit},
+			{Text: "Status", Widget: container.NewVBox(
+				container.NewHBox(container.NewCenter(ui.statusBadge), ui.statusSelect),
+				ui.statusHistory,
+			)},
+			{Text: "Groups", Widget: ui.projectGroupsBox},
+			{Text: "Git", Widget: container.NewBorder(nil, nil, nil, container.NewVBox(refreshGitBtn), ui.gitDetails)},
+			{Text: "Code", Widget: container.NewBorder(nil, nil, nil, container.NewVBox(analyzeBtn), ui.codeDetails)},
 		},
 	}
+	for _, input := range ui.customFieldInputs {
+		errorLabel := ui.detailFieldErrors[models.CustomFieldPrefix+input.field.Name]
+		ui.projectDetails.Items = append(ui.projectDetails.Items, &widget.FormItem{
+			Text:   input.field.DisplayName(),
+			Widget: container.NewVBox(input.widget, errorLabel),
+		})
+	}
+	ui.projectDetails.Items = append(ui.projectDetails.Items,
+		&widget.FormItem{Widget: saveBtn},
+		&widget.FormItem{Widget: container.NewHBox(ui.pinBtn, ui.pinUpBtn, ui.pinDownBtn)},
+		&widget.FormItem{Widget: openInVSCodeBtn},
+		&widget.FormItem{Widget: relocateBtn},
+		&widget.FormItem{Widget: removeProjectBtn},
+		&widget.FormItem{Widget: container.NewHBox(ui.readmeUploadBtn, ui.removeReadmeBtn)},
+		&widget.FormItem{Text: "README Viewer", Widget: readmeScrollContainer},
+		&widget.FormItem{Text: "History", Widget: ui.historyBox},
+	)
 
 	formScroll := container.NewScroll(ui.projectDetails)
 
 	ui.projectList.OnSelected = func(id widget.ListItemID) {
-		if id < len(ui.currentProjects) {
-			ui.selectedProjectIndex = id
-			project := ui.currentProjects[id]
+		if project, ok := ui.selectIndex(id); ok {
 			ui.updateProjectDetails(project)
+			ui.refreshGitStatus(project.ID)
 		}
 	}
 
+	detailTabs := container.NewAppTabs(
+		container.NewTabItem("Details", formScroll),
+		container.NewTabItem("Dependencies", ui.createDependenciesPane()),
+	)
+
 	split := container.NewHSplit(
 		projectListContainer,
-		formScroll,
+		detailTabs,
 	)
 	split.Offset = 0.3
 
 	ui.window.SetContent(split)
-
+	ui.window.SetMainMenu(fyne.NewMainMenu(
+		fyne.NewMenu("File",
+			fyne.NewMenuItem("Export Catalog...", ui.showExportCatalogDialog),
+			fyne.NewMenuItem("Import Catalog...", ui.showImportCatalogDialog),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Back Up Now", ui.backupNow),
+			fyne.NewMenuItem("Restore Latest Backup...", func() {
+				ui.offerRestore("Restore the newest intact backup? Changes made since it was taken are only kept in a copy of the current database next to it.")
+			}),
+		),
+	))
+
+	ui.loadPinned()
+	ui.loadGitStatuses()
 	ui.loadProjects()
 }
 
+// onSearchChanged matches the projects fuzzily while a plain pattern is typed
+func (ui *ProjectManagerUI) onSearchChanged(text string) {
+	if !isStructuredQuery(text) {
+		ui.showFuzzyMatches(text)
+	}
+}
+
 // showNewProjectDialog displays a dialog for creating a new project
 func (ui *ProjectManagerUI) showNewProjectDialog() {
 	pathEntry := widget.NewEntry()
@@ -235,65 +503,119 @@ func (ui *ProjectManagerUI) showNewProjectDialog() {
 		}, ui.window)
 	})
 
+	fieldErrors := map[string]*widget.Label{
+		models.FieldPath: newFieldErrorLabel(),
+		models.FieldName: newFieldErrorLabel(),
+	}
+
 	content := &widget.Form{
 		Items: []*widget.FormItem{
-			{Text: "Project Path", Widget: container.NewHBox(pathEntry, pathSelectBtn)},
-			{Text: "Project Name", Widget: nameEntry},
+			{Text: "Project Path", Widget: container.NewVBox(container.NewHBox(pathEntry, pathSelectBtn), fieldErrors[models.FieldPath])},
+			{Text: "Project Name", Widget: container.NewVBox(nameEntry, fieldErrors[models.FieldName])},
 			{Text: "Description", Widget: descriptionEntry},
 		},
 	}
 
-	dialog.ShowCustomConfirm("Create New Project", "Create", "Cancel", content, func(b bool) {
-		if !b {
-			return
-		}
-
-		projectPath := pathEntry.Text
-		projectName := nameEntry.Text
-
-		if projectPath == "" || projectName == "" {
-			dialog.ShowError(fmt.Errorf("project path and name are required"), ui.window)
-			return
-		}
-
-		err := utils.ValidateProjectPath(projectPath)
-		if err != nil {
-			dialog.ShowError(err, ui.window)
-			return
-		}
+	// The dialog is shown again, with the entered values and the problems
+	// pointed out, until the project is valid or the user cancels.
+	var showDialog func()
+	showDialog = func() {
+		dialog.ShowCustomConfirm("Create New Project", "Create", "Cancel", content, func(b bool) {
+			if !b {
+				return
+			}
 
-		readmeContent, _ := utils.ReadReadmeFile(projectPath)
+			project := &models.Project{
+				Name:        nameEntry.Text,
+				Path:        pathEntry.Text,
+				Description: descriptionEntry.Text,
+				LastOpened:  time.Now(),
+			}
 
-		project := &models.Project{
-			Name:        projectName,
-			Path:        projectPath,
-			Description: descriptionEntry.Text,
-			LastOpened:  time.Now(),
-			ReadmePath:  "",
-		}
+			nested, err := ui.projectService.CheckProject(context.Background(), project)
+			if showFieldErrors(err, fieldErrors) {
+				showDialog()
+				return
+			}
+			if err != nil {
+				dialog.ShowError(err, ui.window)
+				return
+			}
 
-		if readmeContent != "" {
-			readmePath := filepath.Join(projectPath, "README.md")
-			err = ioutil.WriteFile(readmePath, []byte(readmeContent), 0644)
-			if err == nil {
-				project.ReadmePath = readmePath
+			if len(nested) == 0 {
+				ui.createProject(project, fieldErrors, showDialog)
+				return
 			}
-		}
 
-		metadata := utils.ScanProjectMetadata(projectPath)
-		if len(metadata) > 0 {
+			message := fmt.Sprintf("%s overlaps with registered projects:", project.Path)
+			for _, other := range nested {
+				message += fmt.Sprintf("\n  %s (%s)", other.Name, other.Path)
+			}
+			dialog.ShowConfirm("Nested Project", message+"\n\nAdd it anyway?", func(confirmed bool) {
+				if !confirmed {
+					showDialog()
+					return
+				}
+				ui.createProject(project, fieldErrors, showDialog)
+			}, ui.window)
+		}, ui.window)
+	}
+	showDialog()
+}
 
-			project.Tags = []string{}
+// createProject stores a project checked by the new project dialog, which is
+// shown again if the project turns out to be registered already
+func (ui *ProjectManagerUI) createProject(project *models.Project, fieldErrors map[string]*widget.Label, showDialog func()) {
+	readmeContent, _ := utils.ReadReadmeFile(project.Path)
+	if readmeContent != "" {
+		readmePath := filepath.Join(project.Path, "README.md")
+		err := ioutil.WriteFile(readmePath, []byte(readmeContent), 0644)
+		if err == nil {
+			project.ReadmePath = readmePath
 		}
+	}
 
-		err = ui.projectService.CreateProject(project)
-		if err != nil {
-			dialog.ShowError(err, ui.window)
-			return
-		}
+	err := ui.projectService.CreateProject(context.Background(), project)
+	if err == nil {
+		ui.analyzeInBackground(project.ID)
+		ui.scanDependenciesInBackground(project.ID)
+		return
+	}
+	var trashed *service.TrashedDuplicateError
+	if errors.As(err, &trashed) {
+		ui.offerRestoreTrashed(trashed.Project, showDialog)
+		return
+	}
+	if errors.Is(err, service.ErrDuplicatePath) {
+		fieldErrors[models.FieldPath].SetText(fmt.Sprintf("%s is already registered", project.Path))
+		fieldErrors[models.FieldPath].Show()
+		showDialog()
+		return
+	}
+	if showFieldErrors(err, fieldErrors) {
+		showDialog()
+		return
+	}
+	dialog.ShowError(err, ui.window)
+}
 
-		ui.loadProjects()
-	}, ui.window)
+// offerRestoreTrashed offers to restore the trashed project registered for
+// the path of a new project, or to go back to the new project dialog
+func (ui *ProjectManagerUI) offerRestoreTrashed(project *models.Project, showDialog func()) {
+	dialog.ShowConfirm(
+		"Project in Trash",
+		fmt.Sprintf("%s belongs to project '%s', which is in the Trash. Restore it?", project.Path, project.Name),
+		func(confirmed bool) {
+			if !confirmed {
+				showDialog()
+				return
+			}
+			if err := ui.projectService.RestoreProject(context.Background(), project.ID); err != nil {
+				dialog.ShowError(fmt.Errorf("failed to restore project: %v", err), ui.window)
+			}
+		},
+		ui.window,
+	)
 }
 
 // showImportProjectsDialog allows selecting directories to import as projects
@@ -323,8 +645,7 @@ func (ui *ProjectManagerUI) showImportProjectsDialog() {
 					return
 				}
 
-				var importedProjects []*models.Project
-				var errors []error
+				var projects []*models.Project
 
 				for _, path := range projectPaths {
 					project := &models.Project{
@@ -342,29 +663,38 @@ func (ui *ProjectManagerUI) showImportProjectsDialog() {
 						}
 					}
 
-					metadata := utils.ScanProjectMetadata(path)
-					if len(metadata) > 0 {
+					project.Tags = extractTagsFromManifests(manifest.Find(path))
 
-						project.Tags = extractTagsFromMetadata(metadata)
-					}
+					projects = append(projects, project)
+				}
 
-					err := ui.projectService.CreateProject(project)
-					if err != nil {
-						errors = append(errors, fmt.Errorf("failed to import %s: %v", path, err))
-					} else {
-						importedProjects = append(importedProjects, project)
-					}
+				result, err := ui.projectService.ImportProjects(context.Background(), projects)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("import failed, no projects were imported: %v", err), ui.window)
+					return
 				}
 
-				if len(errors) > 0 {
-					errorMsg := "Some projects failed to import:\n"
-					for _, e := range errors {
-						errorMsg += e.Error() + "\n"
+				var created []int64
+				for _, project := range result.Created {
+					created = append(created, project.ID)
+				}
+				ui.analyzeInBackground(created...)
+				ui.scanDependenciesInBackground(created...)
+
+				message := fmt.Sprintf("Imported %d new projects.", len(result.Created))
+				if len(result.Existing) > 0 {
+					message += fmt.Sprintf("\n%d projects were already registered and have been refreshed:", len(result.Existing))
+					for _, project := range result.Existing {
+						message += "\n  " + project.Name
 					}
-					dialog.ShowError(fmt.Errorf("%s", errorMsg), ui.window)
 				}
-
-				ui.loadProjects()
+				if len(result.Restored) > 0 {
+					message += fmt.Sprintf("\n%d projects were in the Trash and have been restored:", len(result.Restored))
+					for _, project := range result.Restored {
+						message += "\n  " + project.Name
+					}
+				}
+				dialog.ShowInformation("Import Projects", message, ui.window)
 			},
 			ui.window,
 		)
@@ -372,21 +702,256 @@ func (ui *ProjectManagerUI) showImportProjectsDialog() {
 	}, ui.window)
 }
 
-// extractTagsFromMetadata identifies project types based on metadata files
-func extractTagsFromMetadata(metadata map[string]string) []string {
-	var tags []string
+// showExportCatalogDialog writes the whole catalog to a JSON, CSV or YAML file
+func (ui *ProjectManagerUI) showExportCatalogDialog() {
+	saveDialog := dialog.NewFileSave(func(uc fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		if uc == nil {
+			return
+		}
+		defer uc.Close()
+
+		format, err := catalog.FormatFromPath(uc.URI().Path())
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+
+		records, err := ui.projectService.ExportProjects(context.Background())
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export projects: %v", err), ui.window)
+			return
+		}
+
+		err = catalog.Encode(uc, format, records)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to write catalog: %v", err), ui.window)
+			return
+		}
+
+		dialog.ShowInformation("Export Catalog", fmt.Sprintf("Exported %d projects.", len(records)), ui.window)
+	}, ui.window)
+	saveDialog.SetFileName("projects.json")
+	saveDialog.Show()
+}
+
+// showImportCatalogDialog reads a catalog file and imports it in the chosen mode
+func (ui *ProjectManagerUI) showImportCatalogDialog() {
+	openDialog := dialog.NewFileOpen(func(uc fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		if uc == nil {
+			return
+		}
+		defer uc.Close()
+
+		format, err := catalog.FormatFromPath(uc.URI().Path())
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+
+		records, err := catalog.Decode(uc, format)
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+
+		modeSelect := widget.NewRadioGroup([]string{"Merge", "Replace"}, nil)
+		modeSelect.SetSelected("Merge")
+		dryRunCheck := widget.NewCheck("Dry run (only show what would change)", nil)
+		dryRunCheck.SetChecked(true)
+
+		dialog.ShowForm(
+			fmt.Sprintf("Import %d projects", len(records)),
+			"Import",
+			"Cancel",
+			[]*widget.FormItem{
+				widget.NewFormItem("Mode", modeSelect),
+				widget.NewFormItem("", dryRunCheck),
+			},
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+
+				mode := models.CatalogMerge
+				if modeSelect.Selected == "Replace" {
+					mode = models.CatalogReplace
+				}
+
+				result, err := ui.projectService.ImportCatalog(context.Background(), records, mode, dryRunCheck.Checked)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("import failed, no projects were imported: %v", err), ui.window)
+					return
+				}
+
+				message := fmt.Sprintf("%d created, %d updated, %d unchanged.", len(result.Created), len(result.Updated), len(result.Unchanged))
+				if result.Removed > 0 {
+					message = fmt.Sprintf("%d existing projects removed, %s", result.Removed, message)
+				}
+				if result.DryRun {
+					message = "Dry run, nothing was changed.\n" + message
+				}
+				dialog.ShowInformation("Import Catalog", message, ui.window)
+			},
+			ui.window,
+		)
+	}, ui.window)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".json", ".csv", ".yaml", ".yml"}))
+	openDialog.Show()
+}
+
+// showUsageDialog displays the most used projects this month and the launches per day
+func (ui *ProjectManagerUI) showUsageDialog() {
+	mostUsed, err := ui.projectService.MostUsedThisMonth(context.Background(), 10)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load usage statistics: %v", err), ui.window)
+		return
+	}
+
+	daily, err := ui.projectService.LaunchCounts(context.Background(), models.StatsByDay, time.Now().AddDate(0, 0, -13))
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load usage statistics: %v", err), ui.window)
+		return
+	}
+
+	var mostUsedText strings.Builder
+	if len(mostUsed) == 0 {
+		mostUsedText.WriteString("No projects opened this month")
+	}
+	for i, usage := range mostUsed {
+		fmt.Fprintf(&mostUsedText, "%d. %s: %d opens, last %s\n", i+1, usage.Project.Name, usage.Count, usage.LastLaunched.Format("Jan 2 15:04"))
+	}
 
-	if _, exists := metadata["go.mod"]; exists {
-		tags = append(tags, "Go")
+	var dailyText strings.Builder
+	if len(daily) == 0 {
+		dailyText.WriteString("No projects opened in the last two weeks")
 	}
-	if _, exists := metadata["package.json"]; exists {
-		tags = append(tags, "JavaScript", "Node.js")
+	for _, count := range daily {
+		fmt.Fprintf(&dailyText, "%s  %s %d\n", count.Period, strings.Repeat("▇", min(count.Count, 40)), count.Count)
 	}
-	if _, exists := metadata["pyproject.toml"]; exists {
-		tags = append(tags, "Python")
+
+	dailyLabel := widget.NewLabel(dailyText.String())
+	dailyLabel.TextStyle = fyne.TextStyle{Monospace: true}
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle("Most used this month", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(mostUsedText.String()),
+		widget.NewLabelWithStyle("Opens per day", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		dailyLabel,
+	)
+
+	dialog.ShowCustom("Usage Statistics", "Close", container.NewVScroll(content), ui.window)
+}
+
+// showTrashDialog lists the deleted projects and lets the user restore or purge them
+func (ui *ProjectManagerUI) showTrashDialog() {
+	trashed, err := ui.projectService.ListTrash(context.Background())
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load trash: %v", err), ui.window)
+		return
 	}
-	if _, exists := metadata["pom.xml"]; exists {
-		tags = append(tags, "Java", "Maven")
+
+	var trashDialog dialog.Dialog
+	reopen := func() {
+		trashDialog.Hide()
+		ui.showTrashDialog()
+	}
+
+	entries := container.NewVBox()
+	if len(trashed) == 0 {
+		entries.Add(widget.NewLabel("The Trash is empty"))
+	}
+	for _, project := range trashed {
+		project := project
+		restoreBtn := widget.NewButton("Restore", func() {
+			if err := ui.projectService.RestoreProject(context.Background(), project.ID); err != nil {
+				dialog.ShowError(fmt.Errorf("failed to restore project: %v", err), ui.window)
+				return
+			}
+			reopen()
+		})
+		purgeBtn := widget.NewButton("Delete Permanently", func() {
+			dialog.ShowConfirm(
+				"Delete Permanently",
+				fmt.Sprintf("Permanently delete project '%s'? This action cannot be undone.", project.Name),
+				func(confirmed bool) {
+					if !confirmed {
+						return
+					}
+					if err := ui.projectService.PurgeProject(context.Background(), project.ID); err != nil {
+						dialog.ShowError(fmt.Errorf("failed to delete project: %v", err), ui.window)
+						return
+					}
+					reopen()
+				},
+				ui.window,
+			)
+		})
+		label := widget.NewLabel(fmt.Sprintf("%s\n%s, deleted %s", project.Name, project.Path, project.DeletedAt.Format("Jan 2 15:04")))
+		entries.Add(container.NewBorder(nil, nil, nil, container.NewHBox(restoreBtn, purgeBtn), label))
+	}
+
+	emptyBtn := widget.NewButton("Empty Trash", func() {
+		dialog.ShowConfirm(
+			"Empty Trash",
+			fmt.Sprintf("Permanently delete %d projects? This action cannot be undone.", len(trashed)),
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				if _, err := ui.projectService.PurgeExpiredTrash(context.Background(), 0); err != nil {
+					dialog.ShowError(fmt.Errorf("failed to empty trash: %v", err), ui.window)
+					return
+				}
+				reopen()
+			},
+			ui.window,
+		)
+	})
+	if len(trashed) == 0 {
+		emptyBtn.Disable()
+	}
+
+	scroll := container.NewVScroll(entries)
+	scroll.SetMinSize(fyne.NewSize(600, 400))
+
+	trashDialog = dialog.NewCustom("Trash", "Close", container.NewBorder(nil, emptyBtn, nil, nil, scroll), ui.window)
+	trashDialog.Show()
+}
+
+// extractTagsFromManifests identifies project types based on manifest files
+func extractTagsFromManifests(manifests []string) []string {
+	var tags []string
+	add := func(names ...string) {
+		for _, name := range names {
+			if !slices.Contains(tags, name) {
+				tags = append(tags, name)
+			}
+		}
+	}
+
+	for _, name := range manifests {
+		switch {
+		case name == "go.mod":
+			add("Go")
+		case name == "package.json":
+			add("JavaScript", "Node.js")
+		case name == "pyproject.toml", strings.HasSuffix(name, ".txt"):
+			add("Python")
+		case name == "pom.xml":
+			add("Java", "Maven")
+		case strings.HasPrefix(name, "build.gradle"):
+			add("Java", "Gradle")
+		case name == "Cargo.toml":
+			add("Rust")
+		}
 	}
 
 	return tags
@@ -396,6 +961,18 @@ func extractTagsFromMetadata(metadata map[string]string) []string {
 func (ui *ProjectManagerUI) updateProjectDetails(project models.Project) {
 	ui.projectDetails.Items[0].Widget.(*widget.Label).SetText(project.Name)
 	ui.descriptionEdit.SetText(project.Description)
+	ui.tagsEdit.SetText(formatTagLines(project.Tags))
+	for _, input := range ui.customFieldInputs {
+		input.set(project.CustomFields[input.field.Name])
+	}
+	showFieldErrors(nil, ui.detailFieldErrors)
+	ui.updatePinButtons(project)
+	ui.updateProjectStatus(project)
+	ui.updateProjectGroups(project)
+	ui.updateGitDetails(project)
+	ui.updateCodeDetails(project)
+	ui.updateDependencyDetails(project)
+	ui.updateHistory(project)
 
 	if project.ReadmePath != "" {
 		content, err := ioutil.ReadFile(project.ReadmePath)
@@ -412,6 +989,126 @@ func (ui *ProjectManagerUI) updateProjectDetails(project models.Project) {
 	ui.updateReadmeButtonsVisibility()
 }
 
+// saveProjectDetails stores the edited description, tags and custom fields of the selected project
+func (ui *ProjectManagerUI) saveProjectDetails() {
+	project, ok := ui.selectedProject()
+	if !ok {
+		dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
+		return
+	}
+	project.Description = ui.descriptionEdit.Text
+	project.Tags = parseTagLines(ui.tagsEdit.Text)
+
+	// Copy the map so a failed save leaves the listed project untouched
+	fields := make(map[string]string, len(project.CustomFields)+len(ui.customFieldInputs))
+	for name, value := range project.CustomFields {
+		fields[name] = value
+	}
+	for _, input := range ui.customFieldInputs {
+		fields[input.field.Name] = input.get()
+	}
+	project.CustomFields = fields
+
+	ui.saveProject(project)
+}
+
+// formatTagLines shows tags in the tag editor, one per line
+func formatTagLines(tags []string) string {
+	return strings.Join(tags, "\n")
+}
+
+// parseTagLines reads the tags of the tag editor, skipping empty lines
+func parseTagLines(text string) []string {
+	var tags []string
+	for _, tag := range strings.Split(text, "\n") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// saveProject updates a project; the list shows the stored values once the
+// service reports the update
+func (ui *ProjectManagerUI) saveProject(project models.Project) {
+	err := ui.projectService.UpdateProject(context.Background(), &project)
+	if showFieldErrors(err, ui.detailFieldErrors) {
+		return
+	}
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to save project: %v", err), ui.window)
+	}
+}
+
+// updateHistory fills the history timeline of the details pane
+func (ui *ProjectManagerUI) updateHistory(project models.Project) {
+	ui.historyBox.RemoveAll()
+	if project.ID == 0 {
+		return
+	}
+
+	history, err := ui.projectService.ProjectHistory(context.Background(), project.ID)
+	if err != nil {
+		ui.historyBox.Add(widget.NewLabel(fmt.Sprintf("Error loading history: %v", err)))
+		return
+	}
+	if len(history) == 0 {
+		ui.historyBox.Add(widget.NewLabel("No changes recorded"))
+		return
+	}
+
+	for i, revision := range history {
+		var text strings.Builder
+		fmt.Fprintf(&text, "Revision %d, %s", revision.Revision, revision.ChangedAt.Local().Format("Jan 2 2006 15:04"))
+		for _, change := range revision.Changes {
+			fmt.Fprintf(&text, "\n  %s: %s → %s", change.Field, historyValue(change.OldValue), historyValue(change.NewValue))
+		}
+
+		label := widget.NewLabel(text.String())
+		label.Wrapping = fyne.TextWrapWord
+
+		if i == 0 {
+			ui.historyBox.Add(container.NewBorder(nil, nil, nil, widget.NewLabel("current"), label))
+			continue
+		}
+		ui.historyBox.Add(container.NewBorder(nil, nil, nil, ui.revertButton(project, revision.Revision), label))
+	}
+
+	ui.historyBox.Add(container.NewBorder(nil, nil, nil, ui.revertButton(project, 0), widget.NewLabel("Original version")))
+}
+
+// revertButton returns a button that reverts the project to the given revision after confirmation
+func (ui *ProjectManagerUI) revertButton(project models.Project, revision int) *widget.Button {
+	return widget.NewButton("Revert to this version", func() {
+		dialog.ShowConfirm(
+			"Revert Project",
+			fmt.Sprintf("Revert '%s' to this version? The revert is recorded as a new revision.", project.Name),
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+
+				err := ui.projectService.RevertProject(context.Background(), project.ID, revision)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("failed to revert project: %v", err), ui.window)
+				}
+			},
+			ui.window,
+		)
+	})
+}
+
+// historyValue formats a field value for the history timeline
+func historyValue(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	if utf8.RuneCountInString(value) > 60 {
+		value = string([]rune(value)[:60]) + "…"
+	}
+	return fmt.Sprintf("%q", value)
+}
+
 // uploadReadmeFile allows selecting and attaching a README file to the current project
 func (ui *ProjectManagerUI) uploadReadmeFile() {
 	dialog.ShowFileOpen(func(uc fyne.URIReadCloser, err error) {
@@ -426,35 +1123,46 @@ func (ui *ProjectManagerUI) uploadReadmeFile() {
 
 		filePath := uc.URI().Path()
 
-		selectedIndex := ui.selectedProjectIndex
-		if selectedIndex < 0 || selectedIndex >= len(ui.currentProjects) {
+		project, ok := ui.selectedProject()
+		if !ok {
 			dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
 			return
 		}
-
-		project := ui.currentProjects[selectedIndex]
 		project.ReadmePath = filePath
 
-		err = ui.projectService.UpdateProject(&project)
-		if err != nil {
-			dialog.ShowError(err, ui.window)
-			return
-		}
-
-		ui.currentProjects[selectedIndex] = project
-		ui.updateProjectDetails(project)
+		ui.saveProject(project)
 	}, ui.window)
 }
 
 // loadProjects retrieves and displays projects from the service
 func (ui *ProjectManagerUI) loadProjects() {
-	projects, err := ui.projectService.ListProjects()
+	opts := ui.currentListOptions()
+	total, err := ui.projectService.CountProjects(context.Background(), opts)
 	if err != nil {
 		log.Printf("Error loading projects: %v", err)
 		return
 	}
 
+	// Step back if the current page no longer exists, e.g. after removing its last project.
+	for opts.Offset > 0 && opts.Offset >= total {
+		opts.Offset = max(opts.Offset-pageSize, 0)
+	}
+
+	projects, err := ui.projectService.ListProjects(context.Background(), opts)
+	if err != nil {
+		log.Printf("Error loading projects: %v", err)
+		return
+	}
+
+	ui.listMu.Lock()
+	ui.listOptions.Offset = opts.Offset
+	ui.listedTotal = total
 	ui.currentProjects = projects
+	ui.currentSnippets = nil
+	ui.currentHighlights = nil
+	ui.listMu.Unlock()
+
+	ui.updatePager(len(projects), total)
 
 	if ui.projectList != nil {
 		ui.projectList.Refresh()
@@ -465,62 +1173,183 @@ func (ui *ProjectManagerUI) loadProjects() {
 	}
 }
 
+// updatePager shows the range of listed projects and enables the page buttons
+func (ui *ProjectManagerUI) updatePager(shown, total int) {
+	if ui.pageLabel == nil {
+		return
+	}
+	offset := ui.currentListOptions().Offset
+
+	if shown == 0 {
+		ui.pageLabel.SetText(fmt.Sprintf("0 of %d", total))
+	} else {
+		ui.pageLabel.SetText(fmt.Sprintf("%d-%d of %d", offset+1, offset+shown, total))
+	}
+
+	if offset > 0 {
+		ui.prevPageBtn.Enable()
+	} else {
+		ui.prevPageBtn.Disable()
+	}
+	if offset+shown < total {
+		ui.nextPageBtn.Enable()
+	} else {
+		ui.nextPageBtn.Disable()
+	}
+}
+
 // performSearch filters projects based on query text
-func (ui *ProjectManagerUI) performSearch(query string) {
-	if query == "" {
+func (ui *ProjectManagerUI) performSearch(queryText string) {
+	ui.searchError.Hide()
+
+	if strings.TrimSpace(queryText) == "" {
 		ui.loadProjects()
 		return
 	}
 
-	projects, err := ui.projectService.SearchProjects(query)
+	results, err := ui.projectService.SearchProjects(context.Background(), queryText)
+	var syntaxErr *query.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		ui.showSearchError(queryText, syntaxErr)
+		return
+	}
 	if err != nil {
 		dialog.ShowError(fmt.Errorf("search failed: %v", err), ui.window)
 		return
 	}
 
-	ui.currentProjects = projects
+	projects := make([]models.Project, 0, len(results))
+	snippets := make(map[int64][]models.SnippetFragment, len(results))
+	for _, result := range results {
+		projects = append(projects, result.Project)
+		snippets[result.Project.ID] = result.Snippet
+	}
+
+	ui.setListed(projects, snippets, nil)
 
 	ui.projectList.Refresh()
 
-	ui.selectedProjectIndex = -1
+	ui.clearSelection()
 	ui.updateProjectDetails(models.Project{})
 
 	dialog.ShowInformation(
 		"Search Results",
-		fmt.Sprintf("Found %d projects matching '%s'", len(projects), query),
+		fmt.Sprintf("Found %d projects matching '%s'", len(projects), queryText),
 		ui.window,
 	)
 }
 
-// removeReadmeFile removes the README file association from the current project
-func (ui *ProjectManagerUI) removeReadmeFile() {
-	selectedIndex := ui.selectedProjectIndex
-	if selectedIndex < 0 || selectedIndex >= len(ui.currentProjects) {
-		dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
+// showFuzzyMatches lists the projects matching a fuzzy pattern, best match first
+func (ui *ProjectManagerUI) showFuzzyMatches(pattern string) {
+	ui.searchError.Hide()
+
+	if strings.TrimSpace(pattern) == "" {
+		ui.loadProjects()
+		return
+	}
+
+	matches, err := ui.projectService.FuzzyFindProjects(context.Background(), pattern, 0)
+	if err != nil {
+		log.Printf("Error matching projects: %v", err)
 		return
 	}
 
-	project := ui.currentProjects[selectedIndex]
+	projects := make([]models.Project, 0, len(matches))
+	highlights := make(map[int64][]int, len(matches))
+	for _, match := range matches {
+		projects = append(projects, match.Project)
+		highlights[match.Project.ID] = match.NamePositions
+	}
 
-	project.ReadmePath = ""
+	ui.setListed(projects, nil, highlights)
+	ui.projectList.UnselectAll()
+	ui.projectList.Refresh()
+	ui.clearSelection()
+	if len(projects) > 0 {
+		ui.projectList.Select(0)
+	}
+}
 
-	err := ui.projectService.UpdateProject(&project)
+// openProject launches a project in VS Code
+func (ui *ProjectManagerUI) openProject(project models.Project) {
+	err := ui.vsCodeLauncher.OpenProject(context.Background(), &project)
 	if err != nil {
-		dialog.ShowError(err, ui.window)
+		dialog.ShowError(fmt.Errorf("failed to open project in VSCode: %v", err), ui.window)
+	}
+}
+
+// isStructuredQuery reports whether text uses the search syntax rather than being a plain fuzzy pattern
+func isStructuredQuery(text string) bool {
+	for _, field := range strings.Fields(text) {
+		if field == "OR" || strings.HasPrefix(field, "-") || strings.ContainsAny(field, `:"`) {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightRunes splits text into fragments, highlighting the runes at the given positions
+func highlightRunes(text string, positions []int) []models.SnippetFragment {
+	if len(positions) == 0 {
+		return []models.SnippetFragment{{Text: text}}
+	}
+
+	marked := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		marked[pos] = true
+	}
+
+	var fragments []models.SnippetFragment
+	for i, r := range []rune(text) {
+		last := len(fragments) - 1
+		if last >= 0 && fragments[last].Highlighted == marked[i] {
+			fragments[last].Text += string(r)
+			continue
+		}
+		fragments = append(fragments, models.SnippetFragment{Text: string(r), Highlighted: marked[i]})
+	}
+	return fragments
+}
+
+// showSearchError points at the position of a syntax error below the search bar
+func (ui *ProjectManagerUI) showSearchError(queryText string, syntaxErr *query.SyntaxError) {
+	column := utf8.RuneCountInString(queryText[:min(syntaxErr.Pos, len(queryText))])
+	ui.searchError.SetText(fmt.Sprintf("%s\n%s^ %s", queryText, strings.Repeat(" ", column), syntaxErr.Msg))
+	ui.searchError.Show()
+}
+
+// snippetSegments converts a search snippet into rich text with the matched terms in bold
+func snippetSegments(fragments []models.SnippetFragment) []widget.RichTextSegment {
+	var segments []widget.RichTextSegment
+	for _, fragment := range fragments {
+		style := widget.RichTextStyleInline
+		if fragment.Highlighted {
+			style = widget.RichTextStyleStrong
+		}
+		segments = append(segments, &widget.TextSegment{Text: fragment.Text, Style: style})
+	}
+	return segments
+}
+
+// removeReadmeFile removes the README file association from the current project
+func (ui *ProjectManagerUI) removeReadmeFile() {
+	project, ok := ui.selectedProject()
+	if !ok {
+		dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
 		return
 	}
 
-	ui.currentProjects[selectedIndex] = project
-	ui.updateProjectDetails(project)
+	project.ReadmePath = ""
+
+	ui.saveProject(project)
 }
 
 // isReadmeVisible returns true if the current project has a README file
 func (ui *ProjectManagerUI) isReadmeVisible() bool {
-	if ui.selectedProjectIndex < 0 || ui.selectedProjectIndex >= len(ui.currentProjects) {
+	project, ok := ui.selectedProject()
+	if !ok {
 		return false
 	}
-
-	project := ui.currentProjects[ui.selectedProjectIndex]
 	return project.ReadmePath != "" && utils.FileExists(project.ReadmePath)
 }
 
@@ -533,5 +1362,59 @@ func (ui *ProjectManagerUI) updateReadmeButtonsVisibility() {
 
 // Run displays the window and starts the application event loop
 func (ui *ProjectManagerUI) Run() {
-	ui.window.ShowAndRun()
+	ui.window.Show()
+	ui.checkDatabase()
+	ui.startPathWatch()
+	ui.app.Run()
+}
+
+// checkDatabase runs the integrity check at startup and offers a restore if the database is damaged
+func (ui *ProjectManagerUI) checkDatabase() {
+	err := ui.backupService.CheckIntegrity(context.Background())
+	if errors.Is(err, service.ErrCorrupt) {
+		ui.offerRestore(fmt.Sprintf("The project database is damaged:\n%v\n\nRestore the newest intact backup?", err))
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to check database integrity: %v", err)
+	}
+}
+
+// offerRestore asks for confirmation and restores the newest intact backup
+func (ui *ProjectManagerUI) offerRestore(message string) {
+	label := widget.NewLabel(message)
+	label.Wrapping = fyne.TextWrapWord
+
+	confirmDialog := dialog.NewCustomConfirm("Restore Backup", "Restore", "Cancel", label, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		backup, err := ui.backupService.RestoreLatestGood(context.Background())
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to restore backup: %v", err), ui.window)
+			return
+		}
+
+		dialog.ShowInformation("Restore Backup", fmt.Sprintf("Restored the backup from %s.", backup.CreatedAt.Format("Jan 2 2006 15:04")), ui.window)
+		// The restore replaced the database underneath the service, so there
+		// are no events to go by.
+		ui.reloadGroups()
+		ui.loadPinned()
+		ui.loadGitStatuses()
+		ui.loadProjects()
+	}, ui.window)
+	confirmDialog.Resize(fyne.NewSize(500, 250))
+	confirmDialog.Show()
+}
+
+// backupNow writes a backup on request
+func (ui *ProjectManagerUI) backupNow() {
+	backup, err := ui.backupService.Backup(context.Background())
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to back up database: %v", err), ui.window)
+		return
+	}
+
+	dialog.ShowInformation("Back Up Now", fmt.Sprintf("Saved a backup to\n%s", backup.Path), ui.window)
 }
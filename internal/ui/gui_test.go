@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTagLinesRoundTrip(t *testing.T) {
+	tags := []string{"client, acme", "go", "R&D; Labs"}
+	if got := parseTagLines(formatTagLines(tags)); !slices.Equal(got, tags) {
+		t.Errorf("parseTagLines(formatTagLines(%q)) = %q", tags, got)
+	}
+}
+
+func TestParseTagLines(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"go\n\n  cli  \n", []string{"go", "cli"}},
+		{"client, acme\r\ngo", []string{"client, acme", "go"}},
+	}
+	for _, test := range tests {
+		if got := parseTagLines(test.text); !slices.Equal(got, test.want) {
+			t.Errorf("parseTagLines(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
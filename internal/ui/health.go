@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// fixLabels names the automatic fixes on their buttons.
+var fixLabels = map[models.HealthFix]string{
+	models.FixRelocate:     "Relocate",
+	models.FixDetectReadme: "Link README",
+	models.FixArchive:      "Archive",
+}
+
+// showHealthDialog checks the catalog and lists the problems found, each with
+// a button for its fix if it has one
+func (ui *ProjectManagerUI) showHealthDialog() {
+	report, err := ui.healthService.Check(context.Background(), models.HealthOptions{
+		StaleAfter:  time.Duration(ui.config.StaleAfterDays) * 24 * time.Hour,
+		SearchRoots: ui.config.DefaultProjectPaths,
+	})
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to check projects: %v", err), ui.window)
+		return
+	}
+
+	var healthDialog dialog.Dialog
+	// rerun shows the report again after a fix.
+	rerun := func() {
+		healthDialog.Hide()
+		ui.showHealthDialog()
+	}
+
+	entries := container.NewVBox()
+	if len(report.Findings) == 0 {
+		entries.Add(widget.NewLabel("No problems found"))
+	}
+
+	var lastID int64
+	for _, finding := range report.Findings {
+		if finding.Project.ID != lastID {
+			if lastID != 0 {
+				entries.Add(widget.NewSeparator())
+			}
+			entries.Add(widget.NewLabelWithStyle(finding.Project.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+			lastID = finding.Project.ID
+		}
+
+		message := widget.NewLabel(finding.Message)
+		message.Wrapping = fyne.TextWrapWord
+		if fixBtn := ui.healthFixButton(finding, rerun); fixBtn != nil {
+			entries.Add(container.NewBorder(nil, nil, nil, fixBtn, message))
+		} else {
+			entries.Add(message)
+		}
+	}
+
+	summary := widget.NewLabel(fmt.Sprintf("Checked %d projects, found %d problems", report.Checked, len(report.Findings)))
+	recheckBtn := widget.NewButton("Check Again", rerun)
+
+	scroll := container.NewVScroll(entries)
+	scroll.SetMinSize(fyne.NewSize(700, 450))
+
+	healthDialog = dialog.NewCustom("Health Check", "Close", container.NewBorder(summary, recheckBtn, nil, nil, scroll), ui.window)
+	healthDialog.Show()
+}
+
+// healthFixButton returns the button that fixes a finding, or nil if it has
+// to be fixed by hand. Missing projects without a likely new location can
+// still be relocated by picking the folder.
+func (ui *ProjectManagerUI) healthFixButton(finding models.HealthFinding, done func()) *widget.Button {
+	if finding.Issue == models.IssueMissingPath && finding.Fix == models.FixNone {
+		return widget.NewButton("Browse...", func() {
+			ui.browseRelocation(finding.Project)
+		})
+	}
+
+	label, ok := fixLabels[finding.Fix]
+	if !ok {
+		return nil
+	}
+
+	return widget.NewButton(label, func() {
+		if finding.Fix == models.FixRelocate {
+			// relocate also drops the missing marker from the list.
+			if ui.relocate(finding.Project, finding.Target) {
+				done()
+			}
+			return
+		}
+
+		err := ui.healthService.Fix(context.Background(), finding)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to fix %s: %v", finding.Project.Name, err), ui.window)
+			return
+		}
+		done()
+	})
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// The state of the project list is changed both by the UI and by events,
+// which arrive on the goroutine that published them. The methods below hold
+// listMu while they touch it; none of them calls into widgets, whose
+// callbacks read the state again.
+
+// listedProject returns the project at an index of the list.
+func (ui *ProjectManagerUI) listedProject(index int) (models.Project, bool) {
+	ui.listMu.Lock()
+	defer ui.listMu.Unlock()
+	return ui.projectAtLocked(index)
+}
+
+// listedRow returns what the list row at an index shows: the project, the
+// positions of its name matching a fuzzy pattern and its search snippet.
+func (ui *ProjectManagerUI) listedRow(index int) (models.Project, []int, []models.SnippetFragment, bool) {
+	ui.listMu.Lock()
+	defer ui.listMu.Unlock()
+	project, ok := ui.projectAtLocked(index)
+	if !ok {
+		return project, nil, nil, false
+	}
+	return project, ui.currentHighlights[project.ID], ui.currentSnippets[project.ID], true
+}
+
+// selectedProject returns the project shown in the details pane.
+func (ui *ProjectManagerUI) selectedProject() (models.Project, bool) {
+	ui.listMu.Lock()
+	defer ui.listMu.Unlock()
+	return ui.projectAtLocked(ui.selectedProjectIndex)
+}
+
+func (ui *ProjectManagerUI) projectAtLocked(index int) (models.Project, bool) {
+	if index < 0 || index >= len(ui.currentProjects) {
+		return models.Project{}, false
+	}
+	return ui.currentProjects[index], true
+}
+
+// selectIndex makes the project at an index the selected one and returns it.
+// It reports false if there is no project at the index, or if the index
+// already holds the selected project, as after removeListed moved it.
+func (ui *ProjectManagerUI) selectIndex(index int) (models.Project, bool) {
+	ui.listMu.Lock()
+	defer ui.listMu.Unlock()
+	previous, selected := ui.projectAtLocked(ui.selectedProjectIndex)
+	project, ok := ui.projectAtLocked(index)
+	if !ok || (selected && index == ui.selectedProjectIndex && previous.ID == project.ID) {
+		return project, false
+	}
+	ui.selectedProjectIndex = index
+	return project, true
+}
+
+// clearSelection leaves no project selected.
+func (ui *ProjectManagerUI) clearSelection() {
+	ui.listMu.Lock()
+	ui.selectedProjectIndex = -1
+	ui.listMu.Unlock()
+}
+
+// isSelected reports whether the project is the one shown in the details pane.
+func (ui *ProjectManagerUI) isSelected(id int64) bool {
+	project, ok := ui.selectedProject()
+	return ok && project.ID == id
+}
+
+func (ui *ProjectManagerUI) listedCount() int {
+	ui.listMu.Lock()
+	defer ui.listMu.Unlock()
+	return len(ui.currentProjects)
+}
+
+// listedIndex returns the list index of a project, or -1 if it is not listed.
+func (ui *ProjectManagerUI) listedIndex(id int64) int {
+	ui.listMu.Lock()
+	defer ui.listMu.Unlock()
+	return ui.indexLocked(id)
+}
+
+func (ui *ProjectManagerUI) indexLocked(id int64) int {
+	return slices.IndexFunc(ui.currentProjects, func(project models.Project) bool {
+		return project.ID == id
+	})
+}
+
+// setListed replaces the listed projects. snippets and highlights are nil
+// unless the projects are search or fuzzy matching results.
+func (ui *ProjectManagerUI) setListed(projects []models.Project, snippets map[int64][]models.SnippetFragment, highlights map[int64][]int) {
+	ui.listMu.Lock()
+	ui.currentProjects = projects
+	ui.currentSnippets = snippets
+	ui.currentHighlights = highlights
+	ui.listMu.Unlock()
+}
+
+// isBrowsing reports whether the list shows a page of the catalog rather
+// than search or fuzzy matching results.
+func (ui *ProjectManagerUI) isBrowsing() bool {
+	ui.listMu.Lock()
+	defer ui.listMu.Unlock()
+	return ui.browsingLocked()
+}
+
+func (ui *ProjectManagerUI) browsingLocked() bool {
+	return ui.currentSnippets == nil && ui.currentHighlights == nil
+}
+
+// currentListOptions returns a copy of the options the list is loaded with.
+func (ui *ProjectManagerUI) currentListOptions() models.ListOptions {
+	ui.listMu.Lock()
+	defer ui.listMu.Unlock()
+	return ui.listOptions
+}
+
+// changeListOptions applies change to the options the list is loaded with.
+func (ui *ProjectManagerUI) changeListOptions(change func(opts *models.ListOptions)) {
+	ui.listMu.Lock()
+	change(&ui.listOptions)
+	ui.listMu.Unlock()
+}
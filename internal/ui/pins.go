@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+// pinnedMarker prefixes the names of pinned projects in the list.
+func pinnedMarker() widget.RichTextSegment {
+	return &widget.TextSegment{
+		Text:  "★ ",
+		Style: widget.RichTextStyle{ColorName: theme.ColorNamePrimary, Inline: true, TextStyle: fyne.TextStyle{Bold: true}},
+	}
+}
+
+// updatePinButtons labels the pin button for the given project and shows the
+// reordering buttons only for pinned projects
+func (ui *ProjectManagerUI) updatePinButtons(project models.Project) {
+	if project.PinnedPosition > 0 {
+		ui.pinBtn.SetText("Unpin")
+		ui.pinUpBtn.Show()
+		ui.pinDownBtn.Show()
+	} else {
+		ui.pinBtn.SetText("Pin to Top")
+		ui.pinUpBtn.Hide()
+		ui.pinDownBtn.Hide()
+	}
+}
+
+// togglePin pins the selected project at the end of the pinned list, or unpins it
+func (ui *ProjectManagerUI) togglePin() {
+	project, ok := ui.selectedProject()
+	if !ok {
+		dialog.ShowError(fmt.Errorf("no project selected"), ui.window)
+		return
+	}
+
+	var err error
+	if project.PinnedPosition > 0 {
+		err = ui.projectService.UnpinProject(context.Background(), project.ID)
+	} else {
+		err = ui.projectService.PinProject(context.Background(), project.ID, 0)
+	}
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to change pin: %v", err), ui.window)
+	}
+}
+
+// movePin moves the selected pinned project up (negative delta) or down the pinned list
+func (ui *ProjectManagerUI) movePin(delta int) {
+	project, ok := ui.selectedProject()
+	if !ok {
+		return
+	}
+
+	position := project.PinnedPosition + delta
+	if project.PinnedPosition == 0 || position < 1 {
+		return
+	}
+
+	err := ui.projectService.PinProject(context.Background(), project.ID, position)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to move pinned project: %v", err), ui.window)
+	}
+}
+
+// selectProject selects a listed project by ID
+func (ui *ProjectManagerUI) selectProject(id int64) {
+	if index := ui.listedIndex(id); index >= 0 {
+		ui.projectList.Select(index)
+		ui.projectList.ScrollTo(index)
+	}
+}
+
+// loadPinned reads the pinned projects for the tray menu. Events keep them
+// up to date after that.
+func (ui *ProjectManagerUI) loadPinned() {
+	pinned, err := ui.projectService.ListPinned(context.Background())
+	if err != nil {
+		log.Printf("Error loading pinned projects: %v", err)
+		return
+	}
+
+	ui.listMu.Lock()
+	ui.pinned = pinned
+	ui.listMu.Unlock()
+	ui.refreshTray()
+}
+
+// updatePinned applies an event to the pinned projects, refreshing the tray
+// menu if they changed
+func (ui *ProjectManagerUI) updatePinned(event service.Event) {
+	var project models.Project
+	switch e := event.(type) {
+	case service.ProjectCreated:
+		project = e.Project
+	case service.ProjectUpdated:
+		project = e.Project
+	case service.ProjectOpened:
+		project = e.Project
+	}
+
+	ui.listMu.Lock()
+	index := slices.IndexFunc(ui.pinned, func(pinned models.Project) bool {
+		return pinned.ID == event.ProjectID()
+	})
+	if index < 0 && project.PinnedPosition == 0 {
+		ui.listMu.Unlock()
+		return
+	}
+
+	// The tray menu holds on to the old slice, so build a new one.
+	pinned := slices.Clone(ui.pinned)
+	if index >= 0 {
+		pinned = slices.Delete(pinned, index, index+1)
+	}
+	if project.PinnedPosition > 0 {
+		pinned = append(pinned, project)
+		slices.SortStableFunc(pinned, func(a, b models.Project) int {
+			return cmp.Compare(a.PinnedPosition, b.PinnedPosition)
+		})
+	}
+	ui.pinned = pinned
+	ui.listMu.Unlock()
+	ui.refreshTray()
+}
+
+// refreshTray lists the pinned projects in the system tray menu, on desktops that have one
+func (ui *ProjectManagerUI) refreshTray() {
+	desk, ok := ui.app.(desktop.App)
+	if !ok {
+		return
+	}
+
+	ui.listMu.Lock()
+	pinned := ui.pinned
+	ui.listMu.Unlock()
+
+	var items []*fyne.MenuItem
+	for _, project := range pinned {
+		items = append(items, fyne.NewMenuItem(project.Name, func() {
+			err := ui.vsCodeLauncher.OpenProject(context.Background(), &project)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("failed to open project in VSCode: %v", err), ui.window)
+			}
+		}))
+	}
+	if len(items) == 0 {
+		placeholder := fyne.NewMenuItem("No pinned projects", nil)
+		placeholder.Disabled = true
+		items = append(items, placeholder)
+	}
+
+	items = append(items,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Show Project Manager", func() {
+			ui.window.Show()
+			ui.window.RequestFocus()
+		}),
+	)
+
+	desk.SetSystemTrayMenu(fyne.NewMenu("Project Manager", items...))
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/config"
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+	"github.com/Agronomety/ProjectManager/pkg/vscode"
+)
+
+// ProfileSwitcher opens the catalog of the named profile and closes the one
+// in use, returning the configuration and services of the new catalog.
+type ProfileSwitcher func(name string) (*config.Config, service.ProjectService, service.BackupService, error)
+
+// createProfileSelect builds the profile selector, shown only when profiles
+// are configured
+func (ui *ProjectManagerUI) createProfileSelect() fyne.CanvasObject {
+	ui.profileSelect = widget.NewSelect(ui.config.ProfileNames(), nil)
+	ui.profileSelect.SetSelected(ui.config.Profile)
+	ui.profileSelect.OnChanged = ui.switchProfile
+
+	row := container.NewBorder(nil, nil, widget.NewLabel("Profile"), nil, ui.profileSelect)
+	row.Hidden = len(ui.config.Profiles) == 0 || ui.switchProfileFunc == nil
+	return row
+}
+
+// switchProfile replaces the catalog shown by the one of another profile
+func (ui *ProjectManagerUI) switchProfile(name string) {
+	if name == ui.config.Profile {
+		return
+	}
+
+	cfg, projectService, backupService, err := ui.switchProfileFunc(name)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to open profile %s: %v", name, err), ui.window)
+		ui.profileSelect.SetSelected(ui.config.Profile)
+		return
+	}
+
+	// Stop the path watch before the missing projects of the old catalog
+	// are forgotten, so that it cannot bring them back.
+	if ui.stopPathWatch != nil {
+		ui.stopPathWatch()
+	}
+	ui.unsubscribe()
+	ui.config = cfg
+	ui.projectService = projectService
+	ui.backupService = backupService
+	ui.healthService = service.NewHealthService(projectService)
+	ui.vsCodeLauncher = vscode.NewLauncher(projectService, cfg.VSCodePath)
+	ui.unsubscribe = projectService.Subscribe(ui.handleEvent)
+
+	ui.missingMu.Lock()
+	ui.missing = make(map[int64]models.MissingProject)
+	ui.missingMu.Unlock()
+
+	// Group IDs belong to the previous catalog.
+	ui.changeListOptions(func(opts *models.ListOptions) {
+		opts.Groups = nil
+		opts.Offset = 0
+	})
+	ui.selectedGroup = allProjectsNode
+	ui.groupTree.OnSelected = nil
+	ui.reloadGroups()
+	ui.groupTree.Select(allProjectsNode)
+	ui.groupTree.OnSelected = ui.selectGroup
+
+	ui.searchError.Hide()
+	ui.searchEntry.OnChanged, ui.searchEntry.Text = nil, ""
+	ui.searchEntry.Refresh()
+	ui.searchEntry.OnChanged = ui.onSearchChanged
+
+	ui.clearSelection()
+	ui.updateProjectDetails(models.Project{})
+	ui.loadPinned()
+	ui.loadGitStatuses()
+	ui.loadProjects()
+	ui.updateTitle()
+
+	ui.checkDatabase()
+	ui.startPathWatch()
+}
+
+// updateTitle names the profile in the window title when there is a choice of profiles
+func (ui *ProjectManagerUI) updateTitle() {
+	if len(ui.config.Profiles) == 0 {
+		ui.window.SetTitle("Project Manager")
+		return
+	}
+	ui.window.SetTitle(fmt.Sprintf("Project Manager - %s", ui.config.Profile))
+}
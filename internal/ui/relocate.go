@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+// pathCheckInterval is how often the paths of all projects are checked.
+const pathCheckInterval = time.Hour
+
+// startPathWatch starts watching the project paths of the catalog in use,
+// stopping the watch of the previous catalog, if any.
+func (ui *ProjectManagerUI) startPathWatch() {
+	if ui.stopPathWatch != nil {
+		ui.stopPathWatch()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ui.stopPathWatch = cancel
+	go ui.watchProjectPaths(ctx, ui.projectService, ui.config.DefaultProjectPaths)
+}
+
+// watchProjectPaths checks the project paths at once and then periodically
+// until ctx is done, and offers to relocate projects that went missing since
+// the last check. It is given the service and search roots of its catalog,
+// as a profile switch replaces those of the UI.
+func (ui *ProjectManagerUI) watchProjectPaths(ctx context.Context, projectService service.ProjectService, roots []string) {
+	ticker := time.NewTicker(pathCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		newlyMissing, err := ui.checkProjectPaths(ctx, projectService, roots)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			log.Printf("Failed to check project paths: %v", err)
+		case newlyMissing > 0:
+			ui.showMissingProjectsDialog()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkProjectPaths refreshes the set of missing projects and returns how
+// many of them were not missing before. If ctx is done by the time the
+// check finishes, the result is dropped.
+func (ui *ProjectManagerUI) checkProjectPaths(ctx context.Context, projectService service.ProjectService, roots []string) (int, error) {
+	missing, err := projectService.FindMissingProjects(ctx, roots)
+	if err != nil {
+		return 0, err
+	}
+
+	ui.missingMu.Lock()
+	if err := ctx.Err(); err != nil {
+		ui.missingMu.Unlock()
+		return 0, err
+	}
+	newlyMissing := 0
+	current := make(map[int64]models.MissingProject, len(missing))
+	for _, m := range missing {
+		if _, known := ui.missing[m.Project.ID]; !known {
+			newlyMissing++
+		}
+		current[m.Project.ID] = m
+	}
+	ui.missing = current
+	ui.missingMu.Unlock()
+
+	ui.projectList.Refresh()
+	return newlyMissing, nil
+}
+
+func (ui *ProjectManagerUI) isMissing(id int64) bool {
+	ui.missingMu.Lock()
+	defer ui.missingMu.Unlock()
+	_, ok := ui.missing[id]
+	return ok
+}
+
+// missingMarker prefixes the names of missing projects in the list.
+func missingMarker() widget.RichTextSegment {
+	return &widget.TextSegment{
+		Text:  "⚠ ",
+		Style: widget.RichTextStyle{ColorName: theme.ColorNameError, Inline: true, TextStyle: fyne.TextStyle{Bold: true}},
+	}
+}
+
+// showMissingProjectsDialog lists the projects whose folder is gone, with the
+// folders they may have moved to
+func (ui *ProjectManagerUI) showMissingProjectsDialog() {
+	ui.missingMu.Lock()
+	var missing []models.MissingProject
+	for _, m := range ui.missing {
+		missing = append(missing, m)
+	}
+	ui.missingMu.Unlock()
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Project.Name < missing[j].Project.Name
+	})
+
+	var missingDialog dialog.Dialog
+	entries := container.NewVBox()
+	if len(missing) == 0 {
+		entries.Add(widget.NewLabel("All project folders exist"))
+	}
+
+	for _, m := range missing {
+		project := m.Project
+		entries.Add(widget.NewLabelWithStyle(fmt.Sprintf("%s\nwas at %s", project.Name, project.Path), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+
+		if len(m.Candidates) == 0 {
+			entries.Add(widget.NewLabel("No matching folder found"))
+		}
+		for _, candidate := range m.Candidates {
+			path := candidate.Path
+			useBtn := widget.NewButton("Use", func() {
+				if ui.relocate(project, path) {
+					missingDialog.Hide()
+					ui.showMissingProjectsDialog()
+				}
+			})
+			entries.Add(container.NewBorder(nil, nil, nil, useBtn, widget.NewLabel(fmt.Sprintf("%s (%s)", path, candidate.MatchedBy))))
+		}
+
+		entries.Add(container.NewHBox(widget.NewButton("Browse...", func() {
+			missingDialog.Hide()
+			ui.browseRelocation(project)
+		})))
+		entries.Add(widget.NewSeparator())
+	}
+
+	recheckBtn := widget.NewButton("Check Again", func() {
+		if _, err := ui.checkProjectPaths(context.Background(), ui.projectService, ui.config.DefaultProjectPaths); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to check project paths: %v", err), ui.window)
+			return
+		}
+		missingDialog.Hide()
+		ui.showMissingProjectsDialog()
+	})
+
+	scroll := container.NewVScroll(entries)
+	scroll.SetMinSize(fyne.NewSize(600, 400))
+
+	missingDialog = dialog.NewCustom("Missing Projects", "Close", container.NewBorder(nil, recheckBtn, nil, nil, scroll), ui.window)
+	missingDialog.Show()
+}
+
+// browseRelocation lets the user pick the new folder of a project
+func (ui *ProjectManagerUI) browseRelocation(project models.Project) {
+	dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		if uri == nil {
+			return
+		}
+		ui.relocate(project, uri.Path())
+	}, ui.window)
+}
+
+// relocate moves a project to a new path and reports whether it succeeded
+func (ui *ProjectManagerUI) relocate(project models.Project, path string) bool {
+	err := ui.projectService.RelocateProject(context.Background(), project.ID, path)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to relocate project: %v", err), ui.window)
+		return false
+	}
+
+	ui.missingMu.Lock()
+	delete(ui.missing, project.ID)
+	ui.missingMu.Unlock()
+
+	// The update event has refreshed the project before it stopped being
+	// missing, so refresh its row once more to drop the marker.
+	if index := ui.listedIndex(project.ID); index >= 0 {
+		ui.projectList.RefreshItem(index)
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// statusColors are the badge colors of the statuses.
+var statusColors = map[models.Status]color.Color{
+	models.StatusIdea:        color.NRGBA{R: 142, G: 68, B: 173, A: 255},
+	models.StatusActive:      color.NRGBA{R: 39, G: 174, B: 96, A: 255},
+	models.StatusPaused:      color.NRGBA{R: 230, G: 126, B: 34, A: 255},
+	models.StatusMaintenance: color.NRGBA{R: 41, G: 128, B: 185, A: 255},
+	models.StatusDone:        color.NRGBA{R: 22, G: 160, B: 133, A: 255},
+	models.StatusArchived:    color.NRGBA{R: 127, G: 140, B: 141, A: 255},
+}
+
+// Entries of the status filter besides the statuses themselves.
+const (
+	statusFilterCurrent = "All but archived"
+	statusFilterAll     = "All statuses"
+)
+
+// newStatusBadge creates an empty status badge, filled in by setStatusBadge
+func newStatusBadge() *fyne.Container {
+	background := canvas.NewRectangle(color.Transparent)
+	background.CornerRadius = 4
+
+	text := canvas.NewText("", color.White)
+	text.TextSize = theme.CaptionTextSize()
+	text.TextStyle = fyne.TextStyle{Bold: true}
+
+	return container.NewStack(background, container.New(layout.NewCustomPaddedLayout(2, 2, 6, 6), text))
+}
+
+// setStatusBadge shows a status in a badge created by newStatusBadge
+func setStatusBadge(badge *fyne.Container, status models.Status) {
+	background := badge.Objects[0].(*canvas.Rectangle)
+	text := badge.Objects[1].(*fyne.Container).Objects[0].(*canvas.Text)
+
+	background.FillColor = statusColors[status]
+	text.Text = string(status)
+	badge.Hidden = status == ""
+	badge.Refresh()
+}
+
+// createStatusFilter builds the selector restricting the list to one status
+func (ui *ProjectManagerUI) createStatusFilter() *widget.Select {
+	options := []string{statusFilterCurrent, statusFilterAll}
+	for _, status := range models.Statuses {
+		options = append(options, string(status))
+	}
+
+	statusSelect := widget.NewSelect(options, nil)
+	statusSelect.SetSelected(statusFilterCurrent)
+	statusSelect.OnChanged = func(selected string) {
+		ui.changeListOptions(func(opts *models.ListOptions) {
+			opts.Statuses = nil
+			opts.IncludeArchived = selected == statusFilterAll
+			if status, err := models.ParseStatus(selected); err == nil {
+				opts.Statuses = []models.Status{status}
+			}
+			opts.Offset = 0
+		})
+		ui.loadProjects()
+	}
+	return statusSelect
+}
+
+// updateProjectStatus shows the status of a project, the statuses it can
+// change to and its status history in the details pane
+func (ui *ProjectManagerUI) updateProjectStatus(project models.Project) {
+	setStatusBadge(ui.statusBadge, project.Status)
+
+	var options []string
+	for _, status := range project.Status.NextStatuses() {
+		options = append(options, string(status))
+	}
+	ui.statusSelect.OnChanged = nil
+	ui.statusSelect.ClearSelected()
+	ui.statusSelect.SetOptions(options)
+	ui.statusSelect.Hidden = project.ID == 0 || len(options) == 0
+	ui.statusSelect.OnChanged = func(selected string) {
+		ui.changeStatus(project, models.Status(selected))
+	}
+
+	ui.statusHistory.SetText("")
+	if project.ID == 0 {
+		return
+	}
+
+	history, err := ui.projectService.StatusHistory(context.Background(), project.ID)
+	if err != nil {
+		ui.statusHistory.SetText(fmt.Sprintf("Error loading status history: %v", err))
+		return
+	}
+
+	var lines []string
+	for _, change := range history {
+		changedAt := change.ChangedAt.Local().Format("Jan 2 2006 15:04")
+		if change.From == "" {
+			lines = append(lines, fmt.Sprintf("%s  added as %s", changedAt, change.To))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s  %s → %s", changedAt, change.From, change.To))
+		}
+	}
+	ui.statusHistory.SetText(strings.Join(lines, "\n"))
+}
+
+// changeStatus moves a project to another status
+func (ui *ProjectManagerUI) changeStatus(project models.Project, status models.Status) {
+	err := ui.projectService.ChangeStatus(context.Background(), project.ID, status)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to change status: %v", err), ui.window)
+		ui.updateProjectStatus(project)
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/Agronomety/ProjectManager/internal/service"
+)
+
+// newFieldErrorLabel creates the label showing the validation error of a
+// form field below it, hidden while the field is valid.
+func newFieldErrorLabel() *widget.Label {
+	label := widget.NewLabel("")
+	label.Importance = widget.DangerImportance
+	label.Wrapping = fyne.TextWrapWord
+	label.Hide()
+	return label
+}
+
+// showFieldErrors shows the field errors of a *service.ValidationError in
+// the labels of the fields, keyed by field name, and hides the other labels.
+// It reports whether every problem of err is now shown next to its field, so
+// that err needs no other mention; a nil err clears all labels.
+func showFieldErrors(err error, labels map[string]*widget.Label) bool {
+	var invalid *service.ValidationError
+	if !errors.As(err, &invalid) {
+		invalid = &service.ValidationError{}
+	}
+
+	for field, label := range labels {
+		if fieldErr := invalid.Field(field); fieldErr != nil {
+			label.SetText(fieldErr.Error())
+			label.Show()
+		} else {
+			label.Hide()
+		}
+	}
+
+	for _, fieldErr := range invalid.Fields {
+		if _, ok := labels[fieldErr.Field]; !ok {
+			return false
+		}
+	}
+	return len(invalid.Fields) > 0
+}
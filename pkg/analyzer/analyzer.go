@@ -0,0 +1,294 @@
+// Package analyzer counts the lines of code, comments and blank lines of the
+// source files of a project per language, in the manner of tokei or cloc.
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// DefaultMaxFileSize is the size above which files are skipped unless
+// Options say otherwise. Larger files are usually generated or data.
+const DefaultMaxFileSize = 4 << 20
+
+// Options tune an analysis.
+type Options struct {
+	// Workers is the number of files counted at once; zero uses one per CPU.
+	Workers int
+	// MaxFileSize skips larger files; zero uses DefaultMaxFileSize.
+	MaxFileSize int64
+}
+
+// sourceFile is a file found by the walk, with its language if its name
+// tells it.
+type sourceFile struct {
+	path string
+	lang *language
+}
+
+// Analyze walks the directory at root, leaving out .git directories and
+// whatever .gitignore files exclude, and counts the lines of every file in
+// a known language. Files are recognised by extension or name, and files
+// without an extension also by their shebang line. The languages are
+// ordered by lines of code, most first.
+func Analyze(ctx context.Context, root string, opts Options) ([]models.LanguageStats, error) {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = DefaultMaxFileSize
+	}
+
+	if _, err := os.ReadDir(root); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %v", root, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	files := make(chan sourceFile, 64)
+	go func() {
+		defer close(files)
+		var stack ignoreStack
+		if exclude, err := readIgnoreFile(filepath.Join(root, ".git", "info", "exclude"), ""); err == nil && exclude != nil {
+			stack = append(stack, exclude)
+		}
+		walk(ctx, root, "", stack, opts.MaxFileSize, files)
+	}()
+
+	// Each worker counts into its own map; they are merged at the end.
+	results := make([]map[string]*models.LanguageStats, opts.Workers)
+	var wg sync.WaitGroup
+	for i := range results {
+		results[i] = make(map[string]*models.LanguageStats)
+		wg.Add(1)
+		go func(stats map[string]*models.LanguageStats) {
+			defer wg.Done()
+			for file := range files {
+				if ctx.Err() != nil {
+					continue
+				}
+				countFile(file, stats)
+			}
+		}(results[i])
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]*models.LanguageStats)
+	for _, stats := range results {
+		for name, counts := range stats {
+			total, ok := merged[name]
+			if !ok {
+				total = &models.LanguageStats{Language: name}
+				merged[name] = total
+			}
+			total.Files += counts.Files
+			total.Code += counts.Code
+			total.Comments += counts.Comments
+			total.Blanks += counts.Blanks
+		}
+	}
+
+	languages := make([]models.LanguageStats, 0, len(merged))
+	for _, stats := range merged {
+		languages = append(languages, *stats)
+	}
+	slices.SortFunc(languages, func(a, b models.LanguageStats) int {
+		if a.Code != b.Code {
+			return b.Code - a.Code
+		}
+		return strings.Compare(a.Language, b.Language)
+	})
+	return languages, nil
+}
+
+// walk sends the files below dir to files, dir being rel relative to the
+// root. Directories that cannot be read are skipped.
+func walk(ctx context.Context, dir, rel string, stack ignoreStack, maxSize int64, files chan<- sourceFile) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	if ignore, err := readIgnoreFile(filepath.Join(dir, ".gitignore"), rel); err == nil && ignore != nil {
+		stack = append(slices.Clip(stack), ignore)
+	}
+
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+
+		name := entry.Name()
+		entryRel := joinRel(rel, name)
+		// Symbolic links are left alone, so that nothing is counted twice.
+		if entry.Type()&os.ModeSymlink != 0 {
+			continue
+		}
+
+		if entry.IsDir() {
+			if name == ".git" || stack.ignored(entryRel, true) {
+				continue
+			}
+			walk(ctx, filepath.Join(dir, name), entryRel, stack, maxSize, files)
+			continue
+		}
+
+		if !entry.Type().IsRegular() || stack.ignored(entryRel, false) {
+			continue
+		}
+
+		lang := byName(name)
+		if lang == nil && filepath.Ext(name) != "" {
+			continue
+		}
+		if info, err := entry.Info(); err != nil || info.Size() > maxSize {
+			continue
+		}
+
+		select {
+		case files <- sourceFile{path: filepath.Join(dir, name), lang: lang}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// countFile adds the lines of a file to stats. Unreadable and binary files,
+// and files whose language cannot be told, are skipped.
+func countFile(file sourceFile, stats map[string]*models.LanguageStats) {
+	content, err := os.ReadFile(file.path)
+	if err != nil {
+		return
+	}
+	if bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0 {
+		return
+	}
+
+	lang := file.lang
+	if lang == nil {
+		firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+		lang = byShebang(strings.TrimSpace(string(firstLine)))
+		if lang == nil {
+			return
+		}
+	}
+
+	counts, ok := stats[lang.name]
+	if !ok {
+		counts = &models.LanguageStats{Language: lang.name}
+		stats[lang.name] = counts
+	}
+	counts.Files++
+	countLines(string(content), lang, counts)
+}
+
+// countLines classifies every line of content as code, comment or blank.
+// Lines with both code and a comment count as code.
+func countLines(content string, lang *language, counts *models.LanguageStats) {
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return
+	}
+
+	// blockEnd is the delimiter closing the block comment the scan is in.
+	blockEnd := ""
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			counts.Blanks++
+			continue
+		}
+
+		hasCode, hasComment := false, false
+	scan:
+		for i := 0; i < len(line); {
+			rest := line[i:]
+			switch {
+			case blockEnd != "":
+				hasComment = true
+				end := strings.Index(rest, blockEnd)
+				if end < 0 {
+					break scan
+				}
+				i += end + len(blockEnd)
+				blockEnd = ""
+			case rest[0] == ' ' || rest[0] == '\t':
+				i++
+			case hasAnyPrefix(rest, lang.lineComments):
+				hasComment = true
+				break scan
+			default:
+				if start, end, ok := lang.blockComment(rest); ok {
+					hasComment = true
+					blockEnd = end
+					i += len(start)
+					continue
+				}
+
+				hasCode = true
+				if strings.IndexByte(lang.quotes, rest[0]) >= 0 {
+					i += stringLength(rest)
+				} else {
+					i++
+				}
+			}
+		}
+
+		switch {
+		case hasCode:
+			counts.Code++
+		case hasComment:
+			counts.Comments++
+		default:
+			counts.Blanks++
+		}
+	}
+}
+
+// blockComment returns the delimiters of the block comment s starts with.
+func (l *language) blockComment(s string) (start, end string, ok bool) {
+	for _, delimiters := range l.blockComments {
+		if strings.HasPrefix(s, delimiters[0]) {
+			return delimiters[0], delimiters[1], true
+		}
+	}
+	return "", "", false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringLength returns the length of the string literal s starts with, up
+// to the end of s if it is not closed on the line.
+func stringLength(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestCountLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		lang    *language
+		content string
+		want    models.LanguageStats
+	}{
+		{"empty", langGo, "", models.LanguageStats{}},
+		{"blank lines", langGo, "\n  \n\t\n", models.LanguageStats{Blanks: 3}},
+		{"go", langGo, "package main\n\n// comment\n/* block\n   still */\nx := 1 // trailing\n",
+			models.LanguageStats{Code: 2, Comments: 3, Blanks: 1}},
+		{"comment marker in string", langGo, "s := \"// not a comment\"\n\"/*\"\nafter\n",
+			models.LanguageStats{Code: 3}},
+		{"code around block comment", langGo, "a /* c */ b\n/* c */\n",
+			models.LanguageStats{Code: 1, Comments: 1}},
+		{"code after block comment", langGo, "/* start\nend */ x := 1\n",
+			models.LanguageStats{Code: 1, Comments: 1}},
+		{"python docstring", langPython, "\"\"\"Module doc\nmore\"\"\"\ndef f():\n    # note\n    return 1\n",
+			models.LanguageStats{Code: 2, Comments: 3}},
+		{"no final newline", langShell, "echo hi\n# done", models.LanguageStats{Code: 1, Comments: 1}},
+		{"markdown", langMarkdown, "# Title\n\ntext\n", models.LanguageStats{Code: 2, Blanks: 1}},
+	}
+	for _, test := range tests {
+		var got models.LanguageStats
+		countLines(test.content, test.lang, &got)
+		if got != test.want {
+			t.Errorf("%s: got %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".gitignore":        "vendor/\n*.gen.go\n",
+		"main.go":           "package main\n\n// main\nfunc main() {}\n",
+		"a.gen.go":          "package main\n",
+		"vendor/dep/dep.go": "package dep\n",
+		"tools/run":         "#!/usr/bin/env python3\nprint(1)\n",
+		"tools/notes":       "no shebang here\n",
+		"tools/data.bin":    "unknown extension\n",
+		".git/hooks/hook":   "#!/bin/sh\necho hook\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := Analyze(context.Background(), root, Options{Workers: 2})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	want := []models.LanguageStats{
+		{Language: "Go", Files: 1, Code: 2, Comments: 1, Blanks: 1},
+		{Language: "Python", Files: 1, Code: 1, Comments: 1},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("got %+v, want %+v", stats, want)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("stats[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule is one pattern of a .gitignore file.
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	// negate re-includes paths excluded by earlier rules.
+	negate bool
+	// dirOnly rules only match directories.
+	dirOnly bool
+}
+
+// ignoreFile holds the rules of a .gitignore file and the directory it is
+// in, relative to the walked root with forward slashes ("" for the root).
+type ignoreFile struct {
+	dir   string
+	rules []ignoreRule
+}
+
+// readIgnoreFile parses the .gitignore style file at name. Files that do
+// not exist have no rules.
+func readIgnoreFile(name, dir string) (*ignoreFile, error) {
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	ignore := &ignoreFile{dir: dir}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(scanner.Text()); ok {
+			ignore.rules = append(ignore.rules, rule)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ignore.rules) == 0 {
+		return nil, nil
+	}
+	return ignore, nil
+}
+
+// parseIgnoreRule compiles a line of a .gitignore file, following
+// gitignore(5). Blank lines and comments yield no rule.
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	// Trailing spaces are ignored unless escaped.
+	line = strings.TrimRight(line, " \t\r")
+	if strings.HasSuffix(line, `\`) {
+		line += " "
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	// A pattern with a slash before its end is relative to the directory of
+	// the .gitignore file; one without matches at any depth.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; c {
+		case '*':
+			if strings.HasPrefix(line[i:], "**") {
+				rest := line[i+2:]
+				atStart := i == 0 || line[i-1] == '/'
+				switch {
+				case atStart && strings.HasPrefix(rest, "/"):
+					// "**/" matches any number of directories, including none.
+					expr.WriteString("(?:.*/)?")
+					i += 2
+					continue
+				case atStart && rest == "":
+					// A trailing "/**" matches everything inside.
+					expr.WriteString(".*")
+					i++
+					continue
+				}
+			}
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(line[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := line[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(line) {
+				i++
+				expr.WriteString(regexp.QuoteMeta(line[i : i+1]))
+			}
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	pattern, err := regexp.Compile(expr.String())
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+// ignoreStack holds the .gitignore files that apply in the directory being
+// walked, outermost first.
+type ignoreStack []*ignoreFile
+
+// ignored reports whether the path, relative to the walked root with forward
+// slashes, is excluded. As in git, the last matching rule decides, and rules
+// of deeper files come later.
+func (s ignoreStack) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, file := range s {
+		sub := rel
+		if file.dir != "" {
+			var ok bool
+			sub, ok = strings.CutPrefix(rel, file.dir+"/")
+			if !ok {
+				continue
+			}
+		}
+
+		for _, rule := range file.rules {
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			if rule.pattern.MatchString(sub) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// joinRel joins slash separated relative paths, either of which may be empty.
+func joinRel(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return path.Join(dir, name)
+}
@@ -0,0 +1,84 @@
+package analyzer
+
+import "testing"
+
+// newIgnoreFile compiles the lines as the .gitignore file of dir.
+func newIgnoreFile(dir string, lines ...string) *ignoreFile {
+	file := &ignoreFile{dir: dir}
+	for _, line := range lines {
+		if rule, ok := parseIgnoreRule(line); ok {
+			file.rules = append(file.rules, rule)
+		}
+	}
+	return file
+}
+
+func TestIgnored(t *testing.T) {
+	tests := []struct {
+		rules []string
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{[]string{"*.log"}, "app.log", false, true},
+		{[]string{"*.log"}, "deep/dir/app.log", false, true},
+		{[]string{"*.log"}, "app.logs", false, false},
+		{[]string{"/build"}, "build", true, true},
+		{[]string{"/build"}, "src/build", true, false},
+		{[]string{"build/"}, "build", true, true},
+		{[]string{"build/"}, "src/build", true, true},
+		{[]string{"build/"}, "build", false, false},
+		{[]string{"doc/*.txt"}, "doc/notes.txt", false, true},
+		{[]string{"doc/*.txt"}, "doc/old/notes.txt", false, false},
+		{[]string{"doc/*.txt"}, "src/doc/notes.txt", false, false},
+		{[]string{"**/cache"}, "cache", true, true},
+		{[]string{"**/cache"}, "a/b/cache", true, true},
+		{[]string{"a/**/b"}, "a/b", true, true},
+		{[]string{"a/**/b"}, "a/x/y/b", true, true},
+		{[]string{"logs/**"}, "logs/2024/app.txt", false, true},
+		{[]string{"file?.txt"}, "file1.txt", false, true},
+		{[]string{"file?.txt"}, "file10.txt", false, false},
+		{[]string{"file[0-9].txt"}, "file7.txt", false, true},
+		{[]string{"file[0-9].txt"}, "filea.txt", false, false},
+		{[]string{"[!a]b"}, "cb", false, true},
+		{[]string{"[!a]b"}, "ab", false, false},
+		{[]string{"*.log", "!keep.log"}, "keep.log", false, false},
+		{[]string{"*.log", "!keep.log"}, "drop.log", false, true},
+		{[]string{`\#notes`}, "#notes", false, true},
+		{[]string{`\!important`}, "!important", false, true},
+		{[]string{`trailing\ `}, "trailing ", false, true},
+		{[]string{"name   "}, "name", false, true},
+		{[]string{"# comment", ""}, "# comment", false, false},
+	}
+	for _, test := range tests {
+		stack := ignoreStack{newIgnoreFile("", test.rules...)}
+		if got := stack.ignored(test.path, test.isDir); got != test.want {
+			t.Errorf("rules %q: ignored(%q, %v) = %v, want %v", test.rules, test.path, test.isDir, got, test.want)
+		}
+	}
+}
+
+func TestIgnoredNestedFiles(t *testing.T) {
+	stack := ignoreStack{
+		newIgnoreFile("", "*.log", "/out"),
+		newIgnoreFile("sub", "!keep.log", "/out"),
+	}
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"keep.log", false, true},
+		{"sub/keep.log", false, false},
+		{"sub/drop.log", false, true},
+		{"other/keep.log", false, true},
+		{"out", true, true},
+		{"sub/out", true, true},
+		{"sub/deeper/out", true, false},
+	}
+	for _, test := range tests {
+		if got := stack.ignored(test.path, test.isDir); got != test.want {
+			t.Errorf("ignored(%q, %v) = %v, want %v", test.path, test.isDir, got, test.want)
+		}
+	}
+}
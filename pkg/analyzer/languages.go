@@ -0,0 +1,261 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// language describes how to recognise the files of a language and its comments.
+type language struct {
+	name string
+	// lineComments start comments that run to the end of the line.
+	lineComments []string
+	// blockComments are pairs of start and end delimiters.
+	blockComments [][2]string
+	// quotes start string literals ending at the same character on the
+	// same line; comment markers inside them are not comments.
+	quotes string
+	// data marks documentation, data and configuration formats, which
+	// do not say what a project is written in.
+	data bool
+}
+
+var (
+	cStyle      = language{lineComments: []string{"//"}, blockComments: [][2]string{{"/*", "*/"}}, quotes: `"'`}
+	hashStyle   = language{lineComments: []string{"#"}, quotes: `"'`}
+	markupStyle = language{blockComments: [][2]string{{"<!--", "-->"}}}
+)
+
+// withName returns a copy of a comment style for the named language.
+func (l language) withName(name string) *language {
+	l.name = name
+	return &l
+}
+
+var (
+	langGo         = cStyle.withName("Go")
+	langJavaScript = cStyle.withName("JavaScript")
+	langTypeScript = cStyle.withName("TypeScript")
+	langJava       = cStyle.withName("Java")
+	langKotlin     = cStyle.withName("Kotlin")
+	langScala      = cStyle.withName("Scala")
+	langC          = cStyle.withName("C")
+	langCHeader    = cStyle.withName("C Header")
+	langCpp        = cStyle.withName("C++")
+	langCSharp     = cStyle.withName("C#")
+	langObjC       = cStyle.withName("Objective-C")
+	langSwift      = cStyle.withName("Swift")
+	langDart       = cStyle.withName("Dart")
+	langRust       = &language{name: "Rust", lineComments: []string{"//"}, blockComments: [][2]string{{"/*", "*/"}}, quotes: `"`}
+	langPHP        = &language{name: "PHP", lineComments: []string{"//", "#"}, blockComments: [][2]string{{"/*", "*/"}}, quotes: `"'`}
+	langCSS        = &language{name: "CSS", blockComments: [][2]string{{"/*", "*/"}}, quotes: `"'`}
+	langSCSS       = cStyle.withName("SCSS")
+	langLess       = cStyle.withName("Less")
+	langPython     = &language{name: "Python", lineComments: []string{"#"}, blockComments: [][2]string{{`"""`, `"""`}, {"'''", "'''"}}, quotes: `"'`}
+	langRuby       = &language{name: "Ruby", lineComments: []string{"#"}, blockComments: [][2]string{{"=begin", "=end"}}, quotes: `"'`}
+	langShell      = hashStyle.withName("Shell")
+	langPowerShell = &language{name: "PowerShell", lineComments: []string{"#"}, blockComments: [][2]string{{"<#", "#>"}}, quotes: `"'`}
+	langPerl       = hashStyle.withName("Perl")
+	langR          = hashStyle.withName("R")
+	langElixir     = hashStyle.withName("Elixir")
+	langMakefile   = hashStyle.withName("Makefile")
+	langDockerfile = hashStyle.withName("Dockerfile")
+	langCMake      = hashStyle.withName("CMake")
+	langYAML       = &language{name: "YAML", lineComments: []string{"#"}, quotes: `"'`, data: true}
+	langTOML       = &language{name: "TOML", lineComments: []string{"#"}, quotes: `"'`, data: true}
+	langLua        = &language{name: "Lua", lineComments: []string{"--"}, blockComments: [][2]string{{"--[[", "]]"}}, quotes: `"'`}
+	langSQL        = &language{name: "SQL", lineComments: []string{"--"}, blockComments: [][2]string{{"/*", "*/"}}, quotes: `'`}
+	langHaskell    = &language{name: "Haskell", lineComments: []string{"--"}, blockComments: [][2]string{{"{-", "-}"}}, quotes: `"`}
+	langErlang     = &language{name: "Erlang", lineComments: []string{"%"}, quotes: `"`}
+	langClojure    = &language{name: "Clojure", lineComments: []string{";"}, quotes: `"`}
+	langVimScript  = &language{name: "Vim Script", lineComments: []string{`"`}}
+	langHTML       = markupStyle.withName("HTML")
+	langXML        = &language{name: "XML", blockComments: [][2]string{{"<!--", "-->"}}, data: true}
+	langVue        = &language{name: "Vue", lineComments: []string{"//"}, blockComments: [][2]string{{"<!--", "-->"}, {"/*", "*/"}}, quotes: `"'`}
+	langSvelte     = &language{name: "Svelte", lineComments: []string{"//"}, blockComments: [][2]string{{"<!--", "-->"}, {"/*", "*/"}}, quotes: `"'`}
+	langMarkdown   = &language{name: "Markdown", data: true}
+	langJSON       = &language{name: "JSON", quotes: `"`, data: true}
+	langProtobuf   = cStyle.withName("Protocol Buffers")
+	langBatch      = &language{name: "Batch", lineComments: []string{"REM ", "rem ", "::"}}
+)
+
+// extensions maps lower-case file extensions to languages.
+var extensions = map[string]*language{
+	".go":     langGo,
+	".js":     langJavaScript,
+	".mjs":    langJavaScript,
+	".cjs":    langJavaScript,
+	".jsx":    langJavaScript,
+	".ts":     langTypeScript,
+	".tsx":    langTypeScript,
+	".mts":    langTypeScript,
+	".java":   langJava,
+	".kt":     langKotlin,
+	".kts":    langKotlin,
+	".scala":  langScala,
+	".c":      langC,
+	".h":      langCHeader,
+	".cc":     langCpp,
+	".cpp":    langCpp,
+	".cxx":    langCpp,
+	".hpp":    langCpp,
+	".hh":     langCpp,
+	".cs":     langCSharp,
+	".m":      langObjC,
+	".mm":     langObjC,
+	".swift":  langSwift,
+	".dart":   langDart,
+	".rs":     langRust,
+	".php":    langPHP,
+	".css":    langCSS,
+	".scss":   langSCSS,
+	".less":   langLess,
+	".py":     langPython,
+	".pyw":    langPython,
+	".rb":     langRuby,
+	".sh":     langShell,
+	".bash":   langShell,
+	".zsh":    langShell,
+	".ps1":    langPowerShell,
+	".psm1":   langPowerShell,
+	".pl":     langPerl,
+	".pm":     langPerl,
+	".r":      langR,
+	".ex":     langElixir,
+	".exs":    langElixir,
+	".mk":     langMakefile,
+	".cmake":  langCMake,
+	".yml":    langYAML,
+	".yaml":   langYAML,
+	".toml":   langTOML,
+	".lua":    langLua,
+	".sql":    langSQL,
+	".hs":     langHaskell,
+	".erl":    langErlang,
+	".hrl":    langErlang,
+	".clj":    langClojure,
+	".cljs":   langClojure,
+	".vim":    langVimScript,
+	".html":   langHTML,
+	".htm":    langHTML,
+	".xml":    langXML,
+	".vue":    langVue,
+	".svelte": langSvelte,
+	".md":     langMarkdown,
+	".json":   langJSON,
+	".proto":  langProtobuf,
+	".bat":    langBatch,
+	".cmd":    langBatch,
+}
+
+// fileNames maps file names without a telling extension to languages.
+var fileNames = map[string]*language{
+	"makefile":       langMakefile,
+	"gnumakefile":    langMakefile,
+	"dockerfile":     langDockerfile,
+	"cmakelists.txt": langCMake,
+	"rakefile":       langRuby,
+	"gemfile":        langRuby,
+}
+
+// interpreters maps the programs named in shebang lines to languages.
+var interpreters = map[string]*language{
+	"sh":      langShell,
+	"bash":    langShell,
+	"zsh":     langShell,
+	"dash":    langShell,
+	"ksh":     langShell,
+	"python":  langPython,
+	"node":    langJavaScript,
+	"deno":    langTypeScript,
+	"ruby":    langRuby,
+	"perl":    langPerl,
+	"php":     langPHP,
+	"lua":     langLua,
+	"rscript": langR,
+	"pwsh":    langPowerShell,
+	"elixir":  langElixir,
+}
+
+// byName returns the language of a file from its name, or nil if the name
+// does not tell.
+func byName(path string) *language {
+	name := strings.ToLower(filepath.Base(path))
+	if lang, ok := fileNames[name]; ok {
+		return lang
+	}
+	if strings.HasPrefix(name, "dockerfile.") {
+		return langDockerfile
+	}
+	return extensions[filepath.Ext(name)]
+}
+
+// byShebang returns the language named by a shebang line such as
+// "#!/usr/bin/env python3", or nil.
+func byShebang(line string) *language {
+	line, ok := strings.CutPrefix(line, "#!")
+	if !ok {
+		return nil
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	program := filepath.Base(fields[0])
+	if program == "env" {
+		// Skip options of env, such as -S.
+		program = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				program = field
+				break
+			}
+		}
+	}
+
+	// Strip versions, as in python3 or python3.12.
+	program = strings.TrimRight(strings.ToLower(program), "0123456789.")
+	return interpreters[program]
+}
+
+// DominantLanguages returns the programming languages with at least minShare
+// of the lines of code in stats, most code first and at most max of them.
+// Documentation, data and configuration formats such as Markdown or JSON
+// are left out, and do not count towards the share either.
+func DominantLanguages(stats []models.LanguageStats, minShare float64, max int) []string {
+	total := 0
+	for _, lang := range stats {
+		if !isData(lang.Language) {
+			total += lang.Code
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	var dominant []string
+	for _, lang := range stats {
+		if len(dominant) == max {
+			break
+		}
+		if isData(lang.Language) || float64(lang.Code) < minShare*float64(total) {
+			continue
+		}
+		dominant = append(dominant, lang.Language)
+	}
+	return dominant
+}
+
+// isData reports whether the named language is a documentation, data or
+// configuration format.
+func isData(name string) bool {
+	for _, lang := range extensions {
+		if lang.name == name {
+			return lang.data
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestByShebang(t *testing.T) {
+	tests := []struct {
+		line string
+		want *language
+	}{
+		{"#!/bin/sh", langShell},
+		{"#!/bin/bash -e", langShell},
+		{"#!/usr/bin/env python3", langPython},
+		{"#!/usr/bin/python3.12", langPython},
+		{"#!/usr/bin/env -S node --no-warnings", langJavaScript},
+		{"#! /usr/bin/env ruby", langRuby},
+		{"#!/usr/bin/env", nil},
+		{"#!", nil},
+		{"#!/usr/bin/unknown", nil},
+		{"# just a comment", nil},
+		{"package main", nil},
+	}
+	for _, test := range tests {
+		if got := byShebang(test.line); got != test.want {
+			t.Errorf("byShebang(%q) = %v, want %v", test.line, got, test.want)
+		}
+	}
+}
+
+func TestByName(t *testing.T) {
+	tests := []struct {
+		path string
+		want *language
+	}{
+		{"main.go", langGo},
+		{"src/App.TSX", langTypeScript},
+		{"Makefile", langMakefile},
+		{"Dockerfile.dev", langDockerfile},
+		{"CMakeLists.txt", langCMake},
+		{"notes.txt", nil},
+		{"run", nil},
+	}
+	for _, test := range tests {
+		if got := byName(test.path); got != test.want {
+			t.Errorf("byName(%q) = %v, want %v", test.path, got, test.want)
+		}
+	}
+}
+
+func TestDominantLanguages(t *testing.T) {
+	stats := []models.LanguageStats{
+		{Language: "JSON", Code: 5000},
+		{Language: "Go", Code: 700},
+		{Language: "TypeScript", Code: 250},
+		{Language: "Shell", Code: 50},
+	}
+	tests := []struct {
+		minShare float64
+		max      int
+		want     []string
+	}{
+		{0.2, 3, []string{"Go", "TypeScript"}},
+		{0.5, 3, []string{"Go"}},
+		{0.01, 2, []string{"Go", "TypeScript"}},
+		{0.01, 3, []string{"Go", "TypeScript", "Shell"}},
+	}
+	for _, test := range tests {
+		got := DominantLanguages(stats, test.minShare, test.max)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("DominantLanguages(%v, %d) = %v, want %v", test.minShare, test.max, got, test.want)
+		}
+	}
+	if got := DominantLanguages([]models.LanguageStats{{Language: "Markdown", Code: 10}}, 0.1, 3); got != nil {
+		t.Errorf("data formats only: got %v, want nil", got)
+	}
+}
@@ -0,0 +1,90 @@
+// Package git reads information about git repositories using the git command.
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IsRepository reports whether dir is the top of a git working tree.
+func IsRepository(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// RootCommit returns the hash of the first commit of the repository at dir.
+// It stays the same when the repository is moved or cloned, so it identifies
+// a project independently of its path. Repositories with several root commits
+// return the smallest hash.
+func RootCommit(dir string) (string, error) {
+	output, err := exec.Command("git", "-C", dir, "rev-list", "--max-parents=0", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read root commit of %s: %v", dir, err)
+	}
+
+	roots := strings.Fields(string(output))
+	if len(roots) == 0 {
+		return "", fmt.Errorf("repository %s has no commits", dir)
+	}
+
+	sort.Strings(roots)
+	return roots[0], nil
+}
+
+// Remotes returns the fetch URLs of the remotes of the repository at dir,
+// by remote name.
+func Remotes(dir string) (map[string]string, error) {
+	return remotes(context.Background(), dir)
+}
+
+func remotes(ctx context.Context, dir string) (map[string]string, error) {
+	output, err := inspectCommand(ctx, dir, "config", "--get-regexp", `^remote\..*\.url$`).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		// git config exits with 1 when nothing matches.
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read remotes of %s: %v", dir, err)
+	}
+
+	remotes := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		key, url, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".url")
+		remotes[name] = url
+	}
+	return remotes, nil
+}
+
+// NormalizeRemoteURL reduces a remote URL to host and repository path, so
+// that the HTTPS and SSH URLs of the same repository compare equal, as in
+// github.com/owner/repo for both https://github.com/owner/repo.git and
+// git@github.com:owner/repo.
+func NormalizeRemoteURL(url string) string {
+	url = strings.TrimSpace(url)
+	if scheme, rest, ok := strings.Cut(url, "://"); ok && !strings.Contains(scheme, "/") {
+		url = rest
+	} else if host, path, ok := strings.Cut(url, ":"); ok && !strings.Contains(host, "/") {
+		// scp-like syntax: [user@]host:path
+		url = host + "/" + path
+	}
+
+	if at := strings.Index(url, "@"); at >= 0 && at < strings.Index(url+"/", "/") {
+		url = url[at+1:]
+	}
+
+	host, path, _ := strings.Cut(url, "/")
+	host, _, _ = strings.Cut(host, ":")
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	return strings.ToLower(host) + "/" + path
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// Inspect reads the branch, working tree state, last commit and remotes of
+// the repository at dir. The ProjectID and CheckedAt of the result are left
+// for the caller to fill in. Cancelling ctx stops the git commands.
+//
+// Inspect runs in the background while the user may be working in the
+// repository, so it does not let git status refresh the index, which would
+// take the index lock and make the user's own git commands fail.
+func Inspect(ctx context.Context, dir string) (*models.GitStatus, error) {
+	output, err := inspectCommand(ctx, dir, "status", "--porcelain=v2", "--branch").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status of %s: %v", dir, err)
+	}
+
+	status := &models.GitStatus{}
+	hasCommits := parseStatus(string(output), status)
+
+	if hasCommits {
+		output, err = inspectCommand(ctx, dir, "log", "-1", "--format=%ct%x00%an").Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read last commit of %s: %v", dir, err)
+		}
+		seconds, author, _ := strings.Cut(strings.TrimSpace(string(output)), "\x00")
+		if unix, err := strconv.ParseInt(seconds, 10, 64); err == nil {
+			status.LastCommitAt = time.Unix(unix, 0)
+		}
+		status.LastCommitAuthor = author
+	}
+
+	status.Remotes, err = remotes(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// inspectCommand returns a git command run in dir that takes no optional
+// locks and is killed when ctx is cancelled.
+func inspectCommand(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "git", append([]string{"--no-optional-locks", "-C", dir}, args...)...)
+}
+
+// parseStatus fills status from the output of git status --porcelain=v2
+// --branch and reports whether the repository has any commits.
+func parseStatus(output string, status *models.GitStatus) bool {
+	hasCommits := false
+	for _, line := range strings.Split(output, "\n") {
+		if header, ok := strings.CutPrefix(line, "# "); ok {
+			key, value, _ := strings.Cut(header, " ")
+			switch key {
+			case "branch.oid":
+				hasCommits = value != "(initial)"
+			case "branch.head":
+				if value != "(detached)" {
+					status.Branch = value
+				}
+			case "branch.upstream":
+				status.Upstream = value
+			case "branch.ab":
+				// +ahead -behind
+				fmt.Sscanf(value, "+%d -%d", &status.Ahead, &status.Behind)
+			}
+			continue
+		}
+
+		if len(line) < 2 {
+			continue
+		}
+		switch line[0] {
+		case '1', '2':
+			// The XY field holds the staged and unstaged change, '.' for none.
+			if len(line) >= 4 {
+				if line[2] != '.' {
+					status.Staged++
+				}
+				if line[3] != '.' {
+					status.Modified++
+				}
+			}
+		case 'u':
+			status.Conflicted++
+		case '?':
+			status.Untracked++
+		}
+	}
+	return hasCommits
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		want       models.GitStatus
+		hasCommits bool
+	}{
+		{
+			name:       "clean branch with upstream",
+			output:     "# branch.oid 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n# branch.head main\n# branch.upstream origin/main\n# branch.ab +2 -3\n",
+			want:       models.GitStatus{Branch: "main", Upstream: "origin/main", Ahead: 2, Behind: 3},
+			hasCommits: true,
+		},
+		{
+			name:   "new repository",
+			output: "# branch.oid (initial)\n# branch.head main\n? notes.txt\n",
+			want:   models.GitStatus{Branch: "main", Untracked: 1},
+		},
+		{
+			name:       "detached head",
+			output:     "# branch.oid 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n# branch.head (detached)\n",
+			want:       models.GitStatus{},
+			hasCommits: true,
+		},
+		{
+			name: "changes",
+			output: "# branch.oid 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+				"# branch.head feature/x\n" +
+				"1 M. N... 100644 100644 100644 abc abc staged.go\n" +
+				"1 .M N... 100644 100644 100644 abc abc modified.go\n" +
+				"1 MM N... 100644 100644 100644 abc abc both.go\n" +
+				"2 R. N... 100644 100644 100644 abc abc R100 new.go\told.go\n" +
+				"u UU N... 100644 100644 100644 100644 abc abc abc conflict.go\n" +
+				"? a.txt\n" +
+				"? b.txt\n" +
+				"! ignored.log\n",
+			want:       models.GitStatus{Branch: "feature/x", Staged: 3, Modified: 2, Untracked: 2, Conflicted: 1},
+			hasCommits: true,
+		},
+		{
+			name:   "empty output",
+			output: "",
+			want:   models.GitStatus{},
+		},
+	}
+	for _, test := range tests {
+		var status models.GitStatus
+		hasCommits := parseStatus(test.output, &status)
+		if hasCommits != test.hasCommits {
+			t.Errorf("%s: hasCommits = %v, want %v", test.name, hasCommits, test.hasCommits)
+		}
+		if !reflect.DeepEqual(status, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, status, test.want)
+		}
+	}
+}
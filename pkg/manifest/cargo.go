@@ -0,0 +1,61 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// cargoSections holds the dependency tables of a Cargo.toml file, either at
+// the top level or for a target.
+type cargoSections struct {
+	Dependencies      map[string]any `toml:"dependencies"`
+	DevDependencies   map[string]any `toml:"dev-dependencies"`
+	BuildDependencies map[string]any `toml:"build-dependencies"`
+}
+
+type cargoManifest struct {
+	cargoSections
+	Target map[string]cargoSections `toml:"target"`
+}
+
+// parseCargo reads the dependencies of a Rust crate, including those of
+// platform specific targets. Build dependencies get the dev scope, like
+// development ones. Dependencies inherited from the workspace have the
+// version "workspace".
+func parseCargo(content []byte, name string) ([]models.Dependency, error) {
+	var crate cargoManifest
+	if _, err := toml.Decode(string(content), &crate); err != nil {
+		return nil, fmt.Errorf("invalid TOML: %v", err)
+	}
+
+	var dependencies []models.Dependency
+	add := func(section map[string]any, scope models.DependencyScope) {
+		for pkgName, spec := range section {
+			version := tableVersion(spec)
+			if table, ok := spec.(map[string]any); ok && table["workspace"] == true {
+				version = "workspace"
+			}
+			dependencies = append(dependencies, models.Dependency{
+				Ecosystem: models.EcosystemCargo,
+				Name:      pkgName,
+				Version:   version,
+				Scope:     scope,
+				Manifest:  name,
+			})
+		}
+	}
+	addSections := func(sections cargoSections) {
+		add(sections.Dependencies, models.ScopeRuntime)
+		add(sections.DevDependencies, models.ScopeDev)
+		add(sections.BuildDependencies, models.ScopeDev)
+	}
+
+	addSections(crate.cargoSections)
+	for _, target := range crate.Target {
+		addSections(target)
+	}
+	return dependencies, nil
+}
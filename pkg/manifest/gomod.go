@@ -0,0 +1,92 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// parseGoMod reads the require directives of a go.mod file, both single
+// and in blocks. Modules marked // indirect are flagged as such.
+func parseGoMod(content []byte, name string) ([]models.Dependency, error) {
+	var dependencies []models.Dependency
+	inRequire := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line, comment, _ := strings.Cut(scanner.Text(), "//")
+		line = strings.TrimSpace(line)
+
+		switch {
+		case inRequire && line == ")":
+			inRequire = false
+			continue
+		case inRequire:
+		default:
+			rest, ok := cutDirective(line, "require")
+			if !ok {
+				continue
+			}
+			if rest == "(" {
+				inRequire = true
+				continue
+			}
+			if rest == "()" {
+				continue
+			}
+			line = rest
+		}
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: malformed requirement %q", lineNumber, line)
+		}
+		path, err := unquoteGo(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+
+		dependencies = append(dependencies, models.Dependency{
+			Ecosystem: models.EcosystemGo,
+			Name:      path,
+			Version:   fields[1],
+			Scope:     models.ScopeRuntime,
+			Manifest:  name,
+			Indirect:  strings.TrimSpace(comment) == "indirect",
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inRequire {
+		return nil, fmt.Errorf("unterminated require block")
+	}
+
+	return dependencies, nil
+}
+
+// cutDirective returns the rest of a go.mod line that starts with the given
+// directive, such as "require", which may be followed by whitespace or
+// directly by the parenthesis of a block.
+func cutDirective(line, directive string) (string, bool) {
+	rest, ok := strings.CutPrefix(line, directive)
+	if !ok || rest == "" || !(rest[0] == ' ' || rest[0] == '\t' || rest[0] == '(') {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// unquoteGo removes the quotes go.mod allows around module paths.
+func unquoteGo(s string) (string, error) {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "`") {
+		return strconv.Unquote(s)
+	}
+	return s, nil
+}
@@ -0,0 +1,70 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestParseGoMod(t *testing.T) {
+	content := `module example.com/app
+
+go 1.24
+
+require example.com/single v1.0.0
+require	example.com/tab v1.1.0 // indirect
+
+require(
+	example.com/nospace v1.2.0
+)
+
+require (
+	// A comment on its own line.
+	"example.com/quoted" v1.3.0
+	example.com/indirect v1.4.0 // indirect
+)
+
+require ()
+
+requirements v0.0.0
+`
+	dependency := func(name, version string, indirect bool) models.Dependency {
+		return models.Dependency{
+			Ecosystem: models.EcosystemGo,
+			Name:      name,
+			Version:   version,
+			Scope:     models.ScopeRuntime,
+			Manifest:  "go.mod",
+			Indirect:  indirect,
+		}
+	}
+	want := []models.Dependency{
+		dependency("example.com/single", "v1.0.0", false),
+		dependency("example.com/tab", "v1.1.0", true),
+		dependency("example.com/nospace", "v1.2.0", false),
+		dependency("example.com/quoted", "v1.3.0", false),
+		dependency("example.com/indirect", "v1.4.0", true),
+	}
+
+	got, err := parseGoMod([]byte(content), "go.mod")
+	if err != nil {
+		t.Fatalf("parseGoMod: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGoMod =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseGoModErrors(t *testing.T) {
+	tests := []string{
+		"require (\n\texample.com/a v1.0.0\n",
+		"require example.com/a\n",
+		"require (\n\texample.com/a v1.0.0 extra\n)\n",
+	}
+	for _, content := range tests {
+		if _, err := parseGoMod([]byte(content), "go.mod"); err == nil {
+			t.Errorf("parseGoMod(%q) succeeded, want an error", content)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package manifest
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+var (
+	// gradleString matches dependencies in string notation, in Groovy or
+	// Kotlin: implementation 'group:name:version' or
+	// testImplementation("group:name").
+	gradleString = regexp.MustCompile(`(?m)^\s*(\w+)\s*\(?\s*["']([^"':\s]+):([^"':\s]+)(?::([^"'\s]+))?["']`)
+	// gradleMap matches dependencies in map notation:
+	// implementation group: 'group', name: 'name', version: 'version'.
+	gradleMap = regexp.MustCompile(`(?m)^\s*(\w+)\s*\(?\s*group\s*[:=]\s*["']([^"']+)["']\s*,\s*name\s*[:=]\s*["']([^"']+)["'](?:\s*,\s*version\s*[:=]\s*["']([^"']+)["'])?`)
+)
+
+// gradleConfigurations maps the configurations of the java, application
+// and Android plugins that are not for tests to scopes.
+var gradleConfigurations = map[string]models.DependencyScope{
+	"implementation":        models.ScopeRuntime,
+	"api":                   models.ScopeRuntime,
+	"compile":               models.ScopeRuntime,
+	"runtime":               models.ScopeRuntime,
+	"compileOnly":           models.ScopeRuntime,
+	"compileOnlyApi":        models.ScopeRuntime,
+	"runtimeOnly":           models.ScopeRuntime,
+	"releaseImplementation": models.ScopeRuntime,
+	"debugImplementation":   models.ScopeDev,
+	"developmentOnly":       models.ScopeDev,
+	"annotationProcessor":   models.ScopeDev,
+	"kapt":                  models.ScopeDev,
+	"ksp":                   models.ScopeDev,
+	"classpath":             models.ScopeDev,
+}
+
+// parseGradle reads the external dependencies of a Gradle build script. A
+// build script is a program, so this is a best effort: only dependencies
+// written out as literals are found, while project and platform
+// dependencies and version catalog references are skipped.
+func parseGradle(content []byte, name string) ([]models.Dependency, error) {
+	var dependencies []models.Dependency
+	for _, pattern := range []*regexp.Regexp{gradleString, gradleMap} {
+		for _, match := range pattern.FindAllStringSubmatch(string(content), -1) {
+			scope, ok := gradleScope(match[1])
+			if !ok {
+				continue
+			}
+			dependencies = append(dependencies, models.Dependency{
+				Ecosystem: models.EcosystemMaven,
+				Name:      match[2] + ":" + match[3],
+				Version:   match[4],
+				Scope:     scope,
+				Manifest:  name,
+			})
+		}
+	}
+	return dependencies, nil
+}
+
+// gradleScope returns the scope of the dependencies of a configuration, and
+// false for names that are not known configurations.
+func gradleScope(configuration string) (models.DependencyScope, bool) {
+	if strings.HasPrefix(configuration, "test") || strings.HasPrefix(configuration, "androidTest") {
+		return models.ScopeTest, true
+	}
+	scope, ok := gradleConfigurations[configuration]
+	return scope, ok
+}
@@ -0,0 +1,48 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestParseGradle(t *testing.T) {
+	tests := []struct {
+		line string
+		want []models.Dependency
+	}{
+		{`implementation 'com.google.guava:guava:33.0.0-jre'`,
+			[]models.Dependency{gradleDependency("com.google.guava:guava", "33.0.0-jre", models.ScopeRuntime)}},
+		{`    api("org.jetbrains.kotlinx:kotlinx-coroutines-core:1.8.0")`,
+			[]models.Dependency{gradleDependency("org.jetbrains.kotlinx:kotlinx-coroutines-core", "1.8.0", models.ScopeRuntime)}},
+		{`testImplementation "junit:junit:4.13.2"`,
+			[]models.Dependency{gradleDependency("junit:junit", "4.13.2", models.ScopeTest)}},
+		{`androidTestImplementation("androidx.test:runner")`,
+			[]models.Dependency{gradleDependency("androidx.test:runner", "", models.ScopeTest)}},
+		{`kapt 'com.google.dagger:dagger-compiler:2.51'`,
+			[]models.Dependency{gradleDependency("com.google.dagger:dagger-compiler", "2.51", models.ScopeDev)}},
+		{`implementation group: 'org.slf4j', name: 'slf4j-api', version: '2.0.12'`,
+			[]models.Dependency{gradleDependency("org.slf4j:slf4j-api", "2.0.12", models.ScopeRuntime)}},
+		{`runtimeOnly(group = "org.postgresql", name = "postgresql")`,
+			[]models.Dependency{gradleDependency("org.postgresql:postgresql", "", models.ScopeRuntime)}},
+		{`implementation project(':core')`, nil},
+		{`implementation platform('org.springframework.boot:spring-boot-dependencies:3.2.0')`, nil},
+		{`implementation libs.guava`, nil},
+		{`id 'org.springframework.boot:plugin:3.2.0'`, nil},
+		{`// implementation 'commented:out:1.0'`, nil},
+	}
+	for _, test := range tests {
+		dependencies, err := parseGradle([]byte(test.line), "build.gradle")
+		if err != nil {
+			t.Fatalf("parseGradle(%q): %v", test.line, err)
+		}
+		if !reflect.DeepEqual(dependencies, test.want) {
+			t.Errorf("parseGradle(%q) = %+v, want %+v", test.line, dependencies, test.want)
+		}
+	}
+}
+
+func gradleDependency(name, version string, scope models.DependencyScope) models.Dependency {
+	return models.Dependency{Ecosystem: models.EcosystemMaven, Name: name, Version: version, Scope: scope, Manifest: "build.gradle"}
+}
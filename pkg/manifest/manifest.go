@@ -0,0 +1,110 @@
+// Package manifest reads the dependencies declared in the package manifests
+// of a project: go.mod, package.json, pyproject.toml, requirements files,
+// pom.xml, build.gradle and Cargo.toml.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// parser reads the dependencies from the content of a manifest. name is the
+// path of the manifest relative to the project.
+type parser func(content []byte, name string) ([]models.Dependency, error)
+
+// parsers maps manifest file names to their parsers.
+var parsers = map[string]parser{
+	"go.mod":           parseGoMod,
+	"package.json":     parsePackageJSON,
+	"pyproject.toml":   parsePyproject,
+	"pom.xml":          parsePom,
+	"build.gradle":     parseGradle,
+	"build.gradle.kts": parseGradle,
+	"Cargo.toml":       parseCargo,
+}
+
+// Error reports a manifest that could not be read or parsed.
+type Error struct {
+	// Manifest is the path of the manifest relative to the project.
+	Manifest string
+	Err      error
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("%s: %v", e.Manifest, e.Err) }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// requirementsPatterns match the pip requirements files of a project.
+var requirementsPatterns = []string{"requirements*.txt", "*requirements.txt", "requirements/*.txt"}
+
+// Find returns the manifests in a project directory that Parse reads,
+// relative to the directory with forward slashes and sorted.
+func Find(dir string) []string {
+	var names []string
+	for name := range parsers {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.Mode().IsRegular() {
+			names = append(names, name)
+		}
+	}
+	for _, pattern := range requirementsPatterns {
+		matches, _ := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern)))
+		for _, match := range matches {
+			rel, err := filepath.Rel(dir, match)
+			if err == nil && !slices.Contains(names, filepath.ToSlash(rel)) {
+				names = append(names, filepath.ToSlash(rel))
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Parse reads every manifest in the top directory of a project, and pip
+// requirements files there or in a requirements directory. The dependencies
+// are ordered by manifest, name and scope. Manifests that cannot be parsed
+// are reported in the error, which joins one *Error per manifest, while the
+// dependencies of the others are still returned.
+func Parse(dir string) ([]models.Dependency, error) {
+	if _, err := os.ReadDir(dir); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %v", dir, err)
+	}
+
+	var dependencies []models.Dependency
+	var errs []error
+	for _, name := range Find(dir) {
+		parse, ok := parsers[name]
+		if !ok {
+			parse = parseRequirements
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			errs = append(errs, &Error{Manifest: name, Err: err})
+			continue
+		}
+
+		found, err := parse(content, name)
+		if err != nil {
+			errs = append(errs, &Error{Manifest: name, Err: err})
+			continue
+		}
+		dependencies = append(dependencies, found...)
+	}
+
+	slices.SortStableFunc(dependencies, func(a, b models.Dependency) int {
+		if a.Manifest != b.Manifest {
+			return strings.Compare(a.Manifest, b.Manifest)
+		}
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return slices.Index(models.DependencyScopes, a.Scope) - slices.Index(models.DependencyScopes, b.Scope)
+	})
+	return dependencies, errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+package manifest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// pom holds the parts of a Maven pom.xml file needed to list its dependencies.
+type pom struct {
+	Version string `xml:"version"`
+	Parent  struct {
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies []pomDependency `xml:"dependencies>dependency"`
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+// pomProperty matches references to properties such as ${junit.version}.
+var pomProperty = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// parsePom reads the dependencies of a Maven project, leaving out those only
+// declared in dependencyManagement. Versions referring to properties of the
+// pom are resolved; the test scope maps to test and all others to runtime.
+func parsePom(content []byte, name string) ([]models.Dependency, error) {
+	var project pom
+	if err := xml.Unmarshal(content, &project); err != nil {
+		return nil, fmt.Errorf("invalid XML: %v", err)
+	}
+
+	properties := make(map[string]string)
+	for _, entry := range project.Properties.Entries {
+		properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+	version := strings.TrimSpace(project.Version)
+	if version == "" {
+		version = strings.TrimSpace(project.Parent.Version)
+	}
+	if version != "" {
+		properties["project.version"] = version
+		properties["version"] = version
+	}
+	resolve := func(value string) string {
+		return pomProperty.ReplaceAllStringFunc(strings.TrimSpace(value), func(ref string) string {
+			if value, ok := properties[ref[2:len(ref)-1]]; ok {
+				return value
+			}
+			return ref
+		})
+	}
+
+	var dependencies []models.Dependency
+	for _, dep := range project.Dependencies {
+		scope := models.ScopeRuntime
+		if strings.TrimSpace(dep.Scope) == "test" {
+			scope = models.ScopeTest
+		}
+		dependencies = append(dependencies, models.Dependency{
+			Ecosystem: models.EcosystemMaven,
+			Name:      resolve(dep.GroupID) + ":" + resolve(dep.ArtifactID),
+			Version:   resolve(dep.Version),
+			Scope:     scope,
+			Manifest:  name,
+		})
+	}
+	return dependencies, nil
+}
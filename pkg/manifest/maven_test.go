@@ -0,0 +1,97 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestParsePom(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <groupId>com.example</groupId>
+  <artifactId>app</artifactId>
+  <version>2.1.0</version>
+  <properties>
+    <junit.version> 5.10.2 </junit.version>
+    <slf4j.group>org.slf4j</slf4j.group>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.example</groupId>
+        <artifactId>managed</artifactId>
+        <version>9.9</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>${slf4j.group}</groupId>
+      <artifactId>slf4j-api</artifactId>
+      <version>2.0.12</version>
+    </dependency>
+    <dependency>
+      <groupId>com.example</groupId>
+      <artifactId>core</artifactId>
+      <version>${project.version}</version>
+      <scope>provided</scope>
+    </dependency>
+    <dependency>
+      <groupId>org.junit.jupiter</groupId>
+      <artifactId>junit-jupiter</artifactId>
+      <version>${junit.version}</version>
+      <scope>test</scope>
+    </dependency>
+    <dependency>
+      <groupId>org.example</groupId>
+      <artifactId>unresolved</artifactId>
+      <version>${missing.version}</version>
+    </dependency>
+  </dependencies>
+</project>`
+	dependencies, err := parsePom([]byte(content), "pom.xml")
+	if err != nil {
+		t.Fatalf("parsePom: %v", err)
+	}
+
+	dependency := func(name, version string, scope models.DependencyScope) models.Dependency {
+		return models.Dependency{Ecosystem: models.EcosystemMaven, Name: name, Version: version, Scope: scope, Manifest: "pom.xml"}
+	}
+	want := []models.Dependency{
+		dependency("org.slf4j:slf4j-api", "2.0.12", models.ScopeRuntime),
+		dependency("com.example:core", "2.1.0", models.ScopeRuntime),
+		dependency("org.junit.jupiter:junit-jupiter", "5.10.2", models.ScopeTest),
+		dependency("org.example:unresolved", "${missing.version}", models.ScopeRuntime),
+	}
+	if !reflect.DeepEqual(dependencies, want) {
+		t.Errorf("got %+v, want %+v", dependencies, want)
+	}
+}
+
+func TestParsePomParentVersion(t *testing.T) {
+	content := `<project>
+  <parent><version>1.4.0</version></parent>
+  <dependencies>
+    <dependency>
+      <groupId>com.example</groupId>
+      <artifactId>sibling</artifactId>
+      <version>${version}</version>
+    </dependency>
+  </dependencies>
+</project>`
+	dependencies, err := parsePom([]byte(content), "pom.xml")
+	if err != nil {
+		t.Fatalf("parsePom: %v", err)
+	}
+	if len(dependencies) != 1 || dependencies[0].Version != "1.4.0" {
+		t.Errorf("got %+v, want version 1.4.0 from the parent", dependencies)
+	}
+}
+
+func TestParsePomInvalid(t *testing.T) {
+	if _, err := parsePom([]byte("<project><dependencies>"), "pom.xml"); err == nil {
+		t.Error("parsePom accepted truncated XML")
+	}
+}
@@ -0,0 +1,44 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// packageJSON holds the dependency sections of a package.json file.
+type packageJSON struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// parsePackageJSON reads the dependencies of an npm package. Development
+// dependencies get the dev scope; regular, peer and optional ones runtime.
+func parsePackageJSON(content []byte, name string) ([]models.Dependency, error) {
+	var pkg packageJSON
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	var dependencies []models.Dependency
+	add := func(section map[string]string, scope models.DependencyScope) {
+		for pkgName, version := range section {
+			dependencies = append(dependencies, models.Dependency{
+				Ecosystem: models.EcosystemNPM,
+				Name:      pkgName,
+				Version:   version,
+				Scope:     scope,
+				Manifest:  name,
+			})
+		}
+	}
+	add(pkg.Dependencies, models.ScopeRuntime)
+	add(pkg.PeerDependencies, models.ScopeRuntime)
+	add(pkg.OptionalDependencies, models.ScopeRuntime)
+	add(pkg.DevDependencies, models.ScopeDev)
+
+	return dependencies, nil
+}
@@ -0,0 +1,198 @@
+package manifest
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+// pyproject holds the dependency sections of a pyproject.toml file, in the
+// standard PEP 621 and PEP 735 form and in the form used by Poetry.
+type pyproject struct {
+	Project struct {
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	// DependencyGroups entries are requirement strings or tables including
+	// another group, which are skipped.
+	DependencyGroups map[string][]any `toml:"dependency-groups"`
+	Tool             struct {
+		Poetry struct {
+			Dependencies    map[string]any `toml:"dependencies"`
+			DevDependencies map[string]any `toml:"dev-dependencies"`
+			Group           map[string]struct {
+				Dependencies map[string]any `toml:"dependencies"`
+			} `toml:"group"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+// devGroups are names of extras and groups holding development tools.
+var devGroups = map[string]bool{
+	"dev": true, "develop": true, "development": true,
+	"lint": true, "linting": true, "format": true, "typing": true,
+	"doc": true, "docs": true,
+}
+
+// groupScope returns the scope of a named group of dependencies. Groups that
+// look like neither test nor development groups get fallback.
+func groupScope(name string, fallback models.DependencyScope) models.DependencyScope {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "test"):
+		return models.ScopeTest
+	case devGroups[name]:
+		return models.ScopeDev
+	}
+	return fallback
+}
+
+// parsePyproject reads the dependencies of a pyproject.toml file. Optional
+// dependencies are runtime dependencies of extra features unless their name
+// says they are for tests or development, while the other groups are dev
+// dependencies unless they are for tests.
+func parsePyproject(content []byte, name string) ([]models.Dependency, error) {
+	var project pyproject
+	if _, err := toml.Decode(string(content), &project); err != nil {
+		return nil, fmt.Errorf("invalid TOML: %v", err)
+	}
+
+	var dependencies []models.Dependency
+	addRequirements := func(requirements []string, scope models.DependencyScope) {
+		for _, requirement := range requirements {
+			if pkgName, version, ok := parseRequirement(requirement); ok {
+				dependencies = append(dependencies, pythonDependency(pkgName, version, scope, name))
+			}
+		}
+	}
+
+	addRequirements(project.Project.Dependencies, models.ScopeRuntime)
+	for group, requirements := range project.Project.OptionalDependencies {
+		addRequirements(requirements, groupScope(group, models.ScopeRuntime))
+	}
+	for group, entries := range project.DependencyGroups {
+		var requirements []string
+		for _, entry := range entries {
+			if requirement, ok := entry.(string); ok {
+				requirements = append(requirements, requirement)
+			}
+		}
+		addRequirements(requirements, groupScope(group, models.ScopeDev))
+	}
+
+	addPoetry := func(section map[string]any, scope models.DependencyScope) {
+		for pkgName, spec := range section {
+			// Poetry lists the supported Python versions among the dependencies.
+			if strings.EqualFold(pkgName, "python") {
+				continue
+			}
+			dependencies = append(dependencies, pythonDependency(pkgName, tableVersion(spec), scope, name))
+		}
+	}
+	poetry := project.Tool.Poetry
+	addPoetry(poetry.Dependencies, models.ScopeRuntime)
+	addPoetry(poetry.DevDependencies, models.ScopeDev)
+	for group, section := range poetry.Group {
+		addPoetry(section.Dependencies, groupScope(group, models.ScopeDev))
+	}
+
+	return dependencies, nil
+}
+
+// parseRequirements reads a pip requirements file. The scope comes from the
+// file name, as in requirements-dev.txt or requirements/test.txt. Options,
+// such as -r or -e, and requirements given only as a path or URL are skipped.
+func parseRequirements(content []byte, name string) ([]models.Dependency, error) {
+	base := strings.ToLower(path.Base(name))
+	scope := models.ScopeRuntime
+	switch {
+	case strings.Contains(base, "test"):
+		scope = models.ScopeTest
+	case strings.Contains(base, "dev"), strings.Contains(base, "lint"), strings.Contains(base, "doc"):
+		scope = models.ScopeDev
+	}
+
+	// Lines ending in a backslash continue on the next line.
+	text := strings.ReplaceAll(strings.ReplaceAll(string(content), "\r\n", "\n"), "\\\n", "")
+
+	var dependencies []models.Dependency
+	for _, line := range strings.Split(text, "\n") {
+		if comment := strings.Index(line, " #"); comment >= 0 {
+			line = line[:comment]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		if pkgName, version, ok := parseRequirement(line); ok {
+			dependencies = append(dependencies, pythonDependency(pkgName, version, scope, name))
+		}
+	}
+	return dependencies, nil
+}
+
+// parseRequirement splits a PEP 508 requirement such as
+// "requests[socks]>=2.31; python_version >= '3.8'" into the package name
+// and version specifier. It fails for paths and URLs without a name.
+func parseRequirement(requirement string) (string, string, bool) {
+	requirement, _, _ = strings.Cut(requirement, ";")
+	requirement = strings.TrimSpace(requirement)
+
+	end := strings.IndexFunc(requirement, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-')
+	})
+	if end < 0 {
+		end = len(requirement)
+	}
+	pkgName := requirement[:end]
+	if pkgName == "" || strings.HasPrefix(pkgName, ".") {
+		return "", "", false
+	}
+
+	rest := strings.TrimSpace(requirement[end:])
+	if strings.HasPrefix(rest, "[") {
+		if closing := strings.Index(rest, "]"); closing >= 0 {
+			rest = strings.TrimSpace(rest[closing+1:])
+		}
+	}
+	switch {
+	case strings.HasPrefix(rest, "@"):
+		// A direct reference: name @ URL
+		rest = strings.TrimSpace(rest[1:])
+	case strings.HasPrefix(rest, "://"), strings.HasPrefix(rest, "/"), strings.HasPrefix(rest, ":"):
+		// A URL or path rather than a name.
+		return "", "", false
+	}
+
+	version := strings.Join(strings.Fields(strings.Trim(rest, "()")), "")
+	return pkgName, version, true
+}
+
+func pythonDependency(pkgName, version string, scope models.DependencyScope, manifest string) models.Dependency {
+	return models.Dependency{
+		Ecosystem: models.EcosystemPyPI,
+		Name:      pkgName,
+		Version:   version,
+		Scope:     scope,
+		Manifest:  manifest,
+	}
+}
+
+// tableVersion returns the version of a dependency given either as a
+// version string or as a table with a version key, as Poetry and Cargo allow.
+func tableVersion(spec any) string {
+	switch spec := spec.(type) {
+	case string:
+		return spec
+	case map[string]any:
+		if version, ok := spec["version"].(string); ok {
+			return version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+package manifest
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+)
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		requirement   string
+		name, version string
+		ok            bool
+	}{
+		{"requests", "requests", "", true},
+		{"requests==2.31.0", "requests", "==2.31.0", true},
+		{"requests >= 2.31, < 3", "requests", ">=2.31,<3", true},
+		{"requests[socks]>=2.31", "requests", ">=2.31", true},
+		{"requests [socks, security] ~= 2.31", "requests", "~=2.31", true},
+		{"requests (>=2.31)", "requests", ">=2.31", true},
+		{"requests>=2.31; python_version >= '3.8'", "requests", ">=2.31", true},
+		{"zope.interface_x-y==6.0", "zope.interface_x-y", "==6.0", true},
+		{"pkg @ https://example.com/pkg-1.0.tar.gz", "pkg", "https://example.com/pkg-1.0.tar.gz", true},
+		{"./local/package", "", "", false},
+		{"../sibling", "", "", false},
+		{"/abs/path", "", "", false},
+		{"https://example.com/pkg.whl", "", "", false},
+		{"", "", "", false},
+	}
+	for _, test := range tests {
+		name, version, ok := parseRequirement(test.requirement)
+		if name != test.name || version != test.version || ok != test.ok {
+			t.Errorf("parseRequirement(%q) = %q, %q, %v; want %q, %q, %v",
+				test.requirement, name, version, ok, test.name, test.version, test.ok)
+		}
+	}
+}
+
+func TestParseRequirementsScope(t *testing.T) {
+	tests := []struct {
+		name string
+		want models.DependencyScope
+	}{
+		{"requirements.txt", models.ScopeRuntime},
+		{"requirements-dev.txt", models.ScopeDev},
+		{"requirements/docs.txt", models.ScopeDev},
+		{"requirements/test.txt", models.ScopeTest},
+		{"requirements-lint.txt", models.ScopeDev},
+	}
+	for _, test := range tests {
+		dependencies, err := parseRequirements([]byte("flask\n"), test.name)
+		if err != nil {
+			t.Fatalf("parseRequirements(%q): %v", test.name, err)
+		}
+		if len(dependencies) != 1 || dependencies[0].Scope != test.want {
+			t.Errorf("parseRequirements(%q) = %+v, want scope %q", test.name, dependencies, test.want)
+		}
+	}
+}
+
+func TestParseRequirements(t *testing.T) {
+	content := "# pinned\r\n" +
+		"-r base.txt\n" +
+		"-e ./local\n" +
+		"flask==3.0.0  # web\n" +
+		"numpy \\\n    >=1.26\n" +
+		"./wheels/local.whl\n" +
+		"\n"
+	dependencies, err := parseRequirements([]byte(content), "requirements.txt")
+	if err != nil {
+		t.Fatalf("parseRequirements: %v", err)
+	}
+	want := []models.Dependency{
+		pythonDependency("flask", "==3.0.0", models.ScopeRuntime, "requirements.txt"),
+		pythonDependency("numpy", ">=1.26", models.ScopeRuntime, "requirements.txt"),
+	}
+	if !reflect.DeepEqual(dependencies, want) {
+		t.Errorf("got %+v, want %+v", dependencies, want)
+	}
+}
+
+func TestParsePyproject(t *testing.T) {
+	content := `
+[project]
+dependencies = ["httpx>=0.27"]
+
+[project.optional-dependencies]
+cli = ["rich"]
+tests = ["pytest>=8"]
+
+[dependency-groups]
+lint = ["ruff", {include-group = "tests"}]
+
+[tool.poetry.dependencies]
+python = "^3.11"
+pydantic = { version = "^2.0", extras = ["email"] }
+
+[tool.poetry.group.test.dependencies]
+hypothesis = "*"
+`
+	dependencies, err := parsePyproject([]byte(content), "pyproject.toml")
+	if err != nil {
+		t.Fatalf("parsePyproject: %v", err)
+	}
+	sort.Slice(dependencies, func(i, j int) bool { return dependencies[i].Name < dependencies[j].Name })
+
+	dependency := func(name, version string, scope models.DependencyScope) models.Dependency {
+		return pythonDependency(name, version, scope, "pyproject.toml")
+	}
+	want := []models.Dependency{
+		dependency("httpx", ">=0.27", models.ScopeRuntime),
+		dependency("hypothesis", "*", models.ScopeTest),
+		dependency("pydantic", "^2.0", models.ScopeRuntime),
+		dependency("pytest", ">=8", models.ScopeTest),
+		dependency("rich", "", models.ScopeRuntime),
+		dependency("ruff", "", models.ScopeDev),
+	}
+	if !reflect.DeepEqual(dependencies, want) {
+		t.Errorf("got %+v, want %+v", dependencies, want)
+	}
+}
@@ -55,6 +55,21 @@ func FindProjectRoots(basePaths []string) ([]string, error) {
 
 // ReadReadmeFile attempts to read README files with various common names
 func ReadReadmeFile(projectPath string) (string, error) {
+	readmePath, err := FindReadmeFile(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(readmePath)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// FindReadmeFile returns the path of the README file of a project, trying
+// various common names
+func FindReadmeFile(projectPath string) (string, error) {
 	readmeNames := []string{
 		"README.md",
 		"readme.md",
@@ -67,9 +82,8 @@ func ReadReadmeFile(projectPath string) (string, error) {
 
 	for _, name := range readmeNames {
 		readmePath := filepath.Join(projectPath, name)
-		content, err := os.ReadFile(readmePath)
-		if err == nil {
-			return string(content), nil
+		if info, err := os.Stat(readmePath); err == nil && !info.IsDir() {
+			return readmePath, nil
 		}
 	}
 
@@ -126,28 +140,6 @@ func GetProjectName(path string) string {
 	return filepath.Base(path)
 }
 
-// ScanProjectMetadata collects additional metadata about a project
-func ScanProjectMetadata(path string) map[string]string {
-	metadata := make(map[string]string)
-
-	files := []string{
-		"go.mod",
-		"package.json",
-		"pyproject.toml",
-		"pom.xml",
-	}
-
-	for _, filename := range files {
-		filePath := filepath.Join(path, filename)
-		content, err := os.ReadFile(filePath)
-		if err == nil {
-			metadata[filename] = string(content)
-		}
-	}
-
-	return metadata
-}
-
 func FileExists(path string) bool {
 	_, err := os.Stat(path)
 	return !os.IsNotExist(err)
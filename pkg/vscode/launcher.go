@@ -1,6 +1,7 @@
 package vscode
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -13,42 +14,54 @@ import (
 	"github.com/Agronomety/ProjectManager/internal/service"
 )
 
+// EditorName identifies VS Code in the launch history.
+const EditorName = "vscode"
+
 type Launcher struct {
 	projectService service.ProjectService
+	// editorPath is the VS Code executable configured for the profile; when
+	// empty, VS Code is started the usual way for the platform.
+	editorPath string
 }
 
-func NewLauncher(projectService service.ProjectService) *Launcher {
+func NewLauncher(projectService service.ProjectService, editorPath string) *Launcher {
 	return &Launcher{
 		projectService: projectService,
+		editorPath:     editorPath,
 	}
 }
 
 // OpenProject launches the given project in VS Code.
-func (l *Launcher) OpenProject(project *models.Project) error {
+func (l *Launcher) OpenProject(ctx context.Context, project *models.Project) error {
 
 	if _, err := os.Stat(project.Path); os.IsNotExist(err) {
 		return fmt.Errorf("project path does not exist: %s", project.Path)
 	}
 
 	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "windows":
+	switch {
+	case l.editorPath != "":
+		cmd = exec.Command(l.editorPath, project.Path)
+	case runtime.GOOS == "windows":
 		cmd = exec.Command("cmd", "/c", "code", project.Path)
-	case "darwin":
+	case runtime.GOOS == "darwin":
 		cmd = exec.Command("open", "-a", "Visual Studio Code", project.Path)
 	default:
 		cmd = exec.Command("code", project.Path)
 	}
 
-	project.LastOpened = time.Now()
-	err := l.projectService.UpdateProject(project)
+	err := cmd.Start()
 	if err != nil {
-		log.Printf("Failed to update last opened time: %v", err)
+		return fmt.Errorf("failed to launch VS Code: %v", err)
 	}
 
-	err = cmd.Start()
+	// Only launches that started are recorded, so a missing editor does
+	// not show up in the launch history.
+	err = l.projectService.RecordLaunch(ctx, project.ID, EditorName)
 	if err != nil {
-		return fmt.Errorf("failed to launch VS Code: %v", err)
+		log.Printf("Failed to record launch: %v", err)
+	} else {
+		project.LastOpened = time.Now()
 	}
 
 	return nil
@@ -56,7 +69,11 @@ func (l *Launcher) OpenProject(project *models.Project) error {
 
 // IsVSCodeInstalled checks if VS Code is installed on the system.
 func (l *Launcher) IsVSCodeInstalled() bool {
-	_, err := exec.LookPath("code")
+	editor := l.editorPath
+	if editor == "" {
+		editor = "code"
+	}
+	_, err := exec.LookPath(editor)
 	return err == nil
 }
 
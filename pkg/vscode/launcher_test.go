@@ -0,0 +1,50 @@
+package vscode
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Agronomety/ProjectManager/internal/models"
+	"github.com/Agronomety/ProjectManager/internal/service"
+	"github.com/Agronomety/ProjectManager/internal/storage"
+)
+
+func TestFailedLaunchIsNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	db, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "projects.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	projectService := service.NewProjectService(storage.NewProjectRepository(db), nil)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	project := &models.Project{Name: "proj", Path: dir}
+	if err := projectService.CreateProject(ctx, project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	launcher := NewLauncher(projectService, filepath.Join(t.TempDir(), "no-such-editor"))
+	if err := launcher.OpenProject(ctx, project); err == nil {
+		t.Fatal("OpenProject succeeded with a missing editor")
+	}
+
+	counts, err := projectService.LaunchCounts(ctx, models.StatsByDay, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("LaunchCounts: %v", err)
+	}
+	for _, count := range counts {
+		if count.Count != 0 {
+			t.Errorf("failed launch was recorded: %+v", counts)
+		}
+	}
+	if !project.LastOpened.IsZero() {
+		t.Error("failed launch set LastOpened")
+	}
+}